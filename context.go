@@ -0,0 +1,209 @@
+package jett
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+/* -------------------------- REQUEST CONTEXT ------------------------- */
+
+// ContextKey is a typed key for values stored in a context.Context.
+// Every key created with NewContextKey is unique, even if two keys share a name,
+// so middleware can't overwrite each other's values by accident.
+//
+//	var userKey = jett.NewContextKey[*User]("user")
+//
+//	ctx = userKey.Set(ctx, user)
+//	user, ok := userKey.Get(ctx)
+type ContextKey[T any] struct {
+	id *contextKeyID
+}
+
+// Identity of a ContextKey, compared by pointer
+type contextKeyID struct {
+	name string
+}
+
+// Create a new ContextKey for values of type T.
+// The name is only used for debugging.
+func NewContextKey[T any](name string) ContextKey[T] {
+	return ContextKey[T]{id: &contextKeyID{name: name}}
+}
+
+// Set returns a copy of ctx that carries the value
+func (k ContextKey[T]) Set(ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, k.id, value)
+}
+
+// Get returns the value stored in ctx, ok is false if no value is present
+func (k ContextKey[T]) Get(ctx context.Context) (value T, ok bool) {
+	if ctx == nil {
+		return value, false
+	}
+	value, ok = ctx.Value(k.id).(T)
+	return value, ok
+}
+
+// String returns the name of the key
+func (k ContextKey[T]) String() string {
+	return "jett.ContextKey(" + k.id.name + ")"
+}
+
+//
+// Values stored in the request context by middleware, along with their accessors.
+//
+
+var apiVersionKey = NewContextKey[string]("apiVersion")
+
+// Returns a copy of ctx that carries the API version requested by the client.
+// Used by middleware.APIVersion
+func WithAPIVersion(ctx context.Context, version string) context.Context {
+	return apiVersionKey.Set(ctx, version)
+}
+
+// APIVersion returns the API version requested by the client (see middleware.APIVersion).
+// Returns the empty string if no version is present.
+func APIVersion(req *http.Request) string {
+	version, _ := apiVersionKey.Get(req.Context())
+	return version
+}
+
+var flagsKey = NewContextKey[map[string]bool]("flags")
+
+// Returns a copy of ctx that carries the feature flags evaluated for the request.
+// Used by middleware.Flags
+func WithFlags(ctx context.Context, flags map[string]bool) context.Context {
+	return flagsKey.Set(ctx, flags)
+}
+
+// Flag reports whether the feature flag is on for the request (see middleware.Flags).
+// Returns false for flags that weren't evaluated.
+func Flag(req *http.Request, name string) bool {
+	flags, _ := flagsKey.Get(req.Context())
+	return flags[name]
+}
+
+var baggageKey = NewContextKey[map[string]string]("baggage")
+
+// Returns a copy of ctx that carries the baggage propagated with the request.
+// Used by middleware.Baggage
+func WithBaggage(ctx context.Context, baggage map[string]string) context.Context {
+	return baggageKey.Set(ctx, baggage)
+}
+
+// Baggage returns the baggage propagated with the request (see middleware.Baggage),
+// nil if there is none. The map must not be modified.
+func Baggage(req *http.Request) map[string]string {
+	baggage, _ := baggageKey.Get(req.Context())
+	return baggage
+}
+
+var logFieldsKey = NewContextKey[*logFields]("logFields")
+
+// LogFieldEntry is a custom field added to the request's log line with LogField
+type LogFieldEntry struct {
+	Key   string
+	Value interface{}
+}
+
+// Fields accumulated over the lifetime of a request
+type logFields struct {
+	mu     sync.Mutex
+	fields []LogFieldEntry
+}
+
+// Returns a copy of ctx in which fields can be accumulated with LogField.
+// Used by middleware.Logger
+func WithLogFields(ctx context.Context) context.Context {
+	return logFieldsKey.Set(ctx, &logFields{})
+}
+
+// LogField adds a custom field (eg. user_id) to the request's log line.
+// Setting the same key again overwrites the previous value.
+//
+// Has no effect unless the request passes through middleware.Logger.
+func LogField(req *http.Request, key string, value interface{}) {
+	lf, ok := logFieldsKey.Get(req.Context())
+	if !ok {
+		return
+	}
+
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+
+	for i := range lf.fields {
+		if lf.fields[i].Key == key {
+			lf.fields[i].Value = value
+			return
+		}
+	}
+	lf.fields = append(lf.fields, LogFieldEntry{Key: key, Value: value})
+}
+
+// LogFields returns the custom fields added with LogField, in the order they were first added
+func LogFields(req *http.Request) []LogFieldEntry {
+	lf, ok := logFieldsKey.Get(req.Context())
+	if !ok {
+		return nil
+	}
+
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+
+	fields := make([]LogFieldEntry, len(lf.fields))
+	copy(fields, lf.fields)
+	return fields
+}
+
+var timingsKey = NewContextKey[*timings]("timings")
+
+// Timing is a named duration recorded with RecordTiming, eg. a database query
+type Timing struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Timings recorded over the lifetime of a request
+type timings struct {
+	mu      sync.Mutex
+	timings []Timing
+}
+
+// Returns a copy of ctx in which timings can be recorded with RecordTiming.
+// Used by middleware.ServerTiming
+func WithTimings(ctx context.Context) context.Context {
+	return timingsKey.Set(ctx, &timings{})
+}
+
+// RecordTiming records how long a named step of the request took (eg. "db", "cache"),
+// to be reported in the Server-Timing response header.
+//
+// Has no effect unless the request passes through middleware.ServerTiming.
+func RecordTiming(req *http.Request, name string, duration time.Duration) {
+	t, ok := timingsKey.Get(req.Context())
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.timings = append(t.timings, Timing{Name: name, Duration: duration})
+}
+
+// Timings returns the timings recorded with RecordTiming, in order
+func Timings(req *http.Request) []Timing {
+	t, ok := timingsKey.Get(req.Context())
+	if !ok {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	recorded := make([]Timing, len(t.timings))
+	copy(recorded, t.timings)
+	return recorded
+}
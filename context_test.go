@@ -0,0 +1,32 @@
+package jett
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextKey(t *testing.T) {
+	userKey := NewContextKey[string]("user")
+	otherKey := NewContextKey[string]("user")
+	countKey := NewContextKey[int]("count")
+
+	ctx := userKey.Set(context.Background(), "jett")
+	ctx = countKey.Set(ctx, 42)
+
+	if user, ok := userKey.Get(ctx); !ok || user != "jett" {
+		t.Fatalf("ContextKey.Get -> Expected : jett, Output : %s", user)
+	}
+
+	if count, ok := countKey.Get(ctx); !ok || count != 42 {
+		t.Fatalf("ContextKey.Get -> Expected : 42, Output : %d", count)
+	}
+
+	// Keys with the same name don't collide
+	if user, ok := otherKey.Get(ctx); ok {
+		t.Fatalf("ContextKey.Get -> Expected a miss, Output : %s", user)
+	}
+
+	if _, ok := userKey.Get(context.Background()); ok {
+		t.Fatalf("ContextKey.Get -> Expected a miss on an empty context")
+	}
+}
@@ -0,0 +1,16 @@
+package jett
+
+import (
+	"fmt"
+	"io"
+	"runtime/pprof"
+	"time"
+)
+
+/* -------------------------- DEBUG SIGNALS ------------------------- */
+
+// Writes the stack traces of all goroutines to out
+func dumpStacks(out io.Writer) {
+	fmt.Fprintf(out, "-> Goroutine dump at %s\n", time.Now().Format(time.RFC3339))
+	pprof.Lookup("goroutine").WriteTo(out, 2)
+}
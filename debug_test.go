@@ -0,0 +1,17 @@
+package jett
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDumpStacks(t *testing.T) {
+	var buf bytes.Buffer
+
+	dumpStacks(&buf)
+
+	if !strings.Contains(buf.String(), "goroutine") || !strings.Contains(buf.String(), "TestDumpStacks") {
+		t.Fatalf("dumpStacks -> Expected a goroutine dump, Output : %s", buf.String())
+	}
+}
@@ -0,0 +1,35 @@
+//go:build !windows
+// +build !windows
+
+package jett
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Dumps all goroutine stacks to out every time the process receives SIGUSR1,
+// without stopping the server. Returns a function to stop listening for the signal.
+func notifyDebugSignals(out io.Writer) func() {
+	debugSignal := make(chan os.Signal, 1)
+	signal.Notify(debugSignal, syscall.SIGUSR1)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-debugSignal:
+				dumpStacks(out)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(debugSignal)
+		close(done)
+	}
+}
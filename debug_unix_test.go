@@ -0,0 +1,54 @@
+//go:build !windows
+// +build !windows
+
+package jett
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// Collects the dumps written by the signal handler's goroutine
+type dumpWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	written chan struct{}
+}
+
+func (dw *dumpWriter) Write(p []byte) (int, error) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	if dw.buf.Len() == 0 {
+		close(dw.written)
+	}
+	return dw.buf.Write(p)
+}
+
+func TestNotifyDebugSignals(t *testing.T) {
+	out := &dumpWriter{written: make(chan struct{})}
+
+	stop := notifyDebugSignals(out)
+	defer stop()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-out.written:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("notifyDebugSignals -> Expected a goroutine dump on SIGUSR1")
+	}
+
+	out.mu.Lock()
+	defer out.mu.Unlock()
+
+	if !strings.HasPrefix(out.buf.String(), "-> Goroutine dump at") {
+		t.Fatalf("notifyDebugSignals -> Expected a goroutine dump, Output : %s", out.buf.String())
+	}
+}
@@ -0,0 +1,13 @@
+//go:build windows
+// +build windows
+
+package jett
+
+import (
+	"io"
+)
+
+// SIGUSR1 isn't available on windows
+func notifyDebugSignals(out io.Writer) func() {
+	return func() {}
+}
@@ -0,0 +1,215 @@
+package jett
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/* -------------------------- CONNECTION DRAINING ------------------------- */
+
+//
+// During graceful shutdown regular connections are given the drain timeout to finish,
+// while connections marked as long-lived (long-polling, SSE, streaming) are given
+// the longer long-lived timeout before they're cut off.
+//
+// See Router.DrainTimeouts and MarkLongLived
+//
+
+var drainConnKey = NewContextKey[*drainConn]("drainConn")
+
+// Connection being served by the development server
+type drainConn struct {
+	conn      net.Conn
+	longLived int32
+}
+
+// Keeps track of all open connections of the development server
+// and the number of requests it served
+type drainTracker struct {
+	mu       sync.Mutex
+	conns    map[net.Conn]*drainConn
+	requests uint64
+}
+
+func newDrainTracker() *drainTracker {
+	return &drainTracker{
+		conns: make(map[net.Conn]*drainConn),
+	}
+}
+
+// http.Server ConnContext hook - registers a new connection and exposes it via the context
+func (t *drainTracker) connContext(ctx context.Context, c net.Conn) context.Context {
+	dc := &drainConn{conn: c}
+
+	t.mu.Lock()
+	t.conns[c] = dc
+	t.mu.Unlock()
+
+	return drainConnKey.Set(ctx, dc)
+}
+
+// http.Server ConnState hook - forgets connections once they're closed or hijacked
+func (t *drainTracker) connState(c net.Conn, state http.ConnState) {
+	if state == http.StateClosed || state == http.StateHijacked {
+		t.mu.Lock()
+		delete(t.conns, c)
+		t.mu.Unlock()
+	}
+}
+
+// Wraps the server's handler to count the requests served.
+// Also clears the connection's long-lived flag once a request is done,
+// a kept-alive connection may go on to serve regular requests.
+func (t *drainTracker) countRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddUint64(&t.requests, 1)
+		next.ServeHTTP(w, req)
+
+		if dc, ok := drainConnKey.Get(req.Context()); ok {
+			atomic.StoreInt32(&dc.longLived, 0)
+		}
+	})
+}
+
+// Closes every open connection that hasn't been marked as long-lived
+func (t *drainTracker) closeShortLived() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for c, dc := range t.conns {
+		if atomic.LoadInt32(&dc.longLived) == 0 {
+			c.Close()
+		}
+	}
+}
+
+// MarkLongLived flags the connection serving req as long-lived (long-polling, SSE etc.)
+// so that it is given the long-lived drain timeout during graceful shutdown.
+// The flag is cleared once the request is done.
+// Handlers should still watch req.Context().Done() to exit once the connection is closed.
+//
+// Has no effect when the request isn't served by Jett's development server.
+func MarkLongLived(req *http.Request) {
+	if dc, ok := drainConnKey.Get(req.Context()); ok {
+		atomic.StoreInt32(&dc.longLived, 1)
+	}
+}
+
+// DrainTimeouts sets how long connections are given to finish during graceful shutdown.
+// - regular -> deadline for regular connections (default 5s)
+// - longLived -> deadline for connections marked with MarkLongLived (default 10s)
+func (r *Router) DrainTimeouts(regular, longLived time.Duration) {
+	r.drainTimeout = regular
+	r.longLivedTimeout = longLived
+}
+
+// ShutdownReport summarizes a graceful shutdown of the development server
+type ShutdownReport struct {
+	// Requests -> total number of requests served
+	Requests uint64
+
+	// Duration -> time taken to drain connections and run the shutdown functions
+	Duration time.Duration
+
+	// ShutdownFns -> number of shutdown functions run
+	ShutdownFns int
+
+	// Err -> error returned by the shutdown, eg. the drain timeout was exceeded
+	Err error
+}
+
+// String formats the report for the server's logs
+func (sr ShutdownReport) String() string {
+	report := fmt.Sprintf("%d requests served, shut down in %s, %d shutdown functions run",
+		sr.Requests, sr.Duration, sr.ShutdownFns)
+	if sr.Err != nil {
+		report += ", error: " + sr.Err.Error()
+	}
+	return report
+}
+
+// Shuts down the server then runs the shutdown functions (in reverse order) and reports on it.
+// The shutdown functions run even if draining the connections fails.
+func (r *Router) gracefulShutdown(server *http.Server, tracker *drainTracker, onShutdownFns ...func()) ShutdownReport {
+	start := time.Now()
+
+	err := r.shutdown(server, tracker)
+
+	totalFns := len(onShutdownFns)
+	if totalFns > 0 {
+		fmt.Println("-> Running shutdown functions...")
+	}
+
+	// Call each shutdown function one by one
+	for i, j := totalFns-1, 1; i >= 0; i, j = i-1, j+1 {
+		fmt.Println("-> ", j, " of ", totalFns)
+		onShutdownFns[i]()
+	}
+
+	return ShutdownReport{
+		Requests:    atomic.LoadUint64(&tracker.requests),
+		Duration:    time.Since(start),
+		ShutdownFns: totalFns,
+		Err:         err,
+	}
+}
+
+// Gracefully shuts down the server, cutting off regular connections after the drain
+// timeout and long-lived ones after the long-lived timeout.
+func (r *Router) shutdown(server *http.Server, tracker *drainTracker) error {
+
+	timeout := r.drainTimeout
+	if r.longLivedTimeout > timeout {
+		timeout = r.longLivedTimeout
+	}
+
+	// context.Background() gives us an empty context
+	// set timeout to avoid keeping zombie conns alive
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Regular connections only get the drain timeout
+	closer := time.AfterFunc(r.drainTimeout, tracker.closeShortLived)
+	defer closer.Stop()
+
+	if err := server.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	// Wait for goroutines tracked with TrackGoroutine, bounded by the same deadline
+	finished := make(chan struct{})
+	go func() {
+		r.background.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TrackGoroutine registers a background goroutine (spawned by a handler) that
+// graceful shutdown waits for before exiting, bounded by the shutdown timeout.
+// Call the returned function once the goroutine is done -
+//
+//	done := r.TrackGoroutine()
+//	go func() {
+//		defer done()
+//		sendWelcomeEmail(user)
+//	}()
+func (r *Router) TrackGoroutine() func() {
+	r.background.Add(1)
+
+	var once sync.Once
+	return func() {
+		once.Do(r.background.Done)
+	}
+}
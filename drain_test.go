@@ -0,0 +1,179 @@
+package jett
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDrainLongLived(t *testing.T) {
+	r := New()
+	r.DrainTimeouts(50*time.Millisecond, 2*time.Second)
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	cutOff := make(chan string, 2)
+
+	wait := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			if name == "long" {
+				MarkLongLived(req)
+			}
+			w.WriteHeader(200)
+			w.(http.Flusher).Flush()
+			started <- struct{}{}
+
+			select {
+			case <-req.Context().Done():
+				cutOff <- name
+			case <-release:
+			}
+		}
+	}
+
+	r.GET("/short", wait("short"))
+	r.GET("/long", wait("long"))
+
+	tracker := newDrainTracker()
+	ts := httptest.NewUnstartedServer(r)
+	ts.Config.ConnContext = tracker.connContext
+	ts.Config.ConnState = tracker.connState
+	ts.Start()
+	defer ts.Close()
+
+	for _, path := range []string{"/short", "/long"} {
+		res, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+	}
+	<-started
+	<-started
+
+	done := make(chan error, 1)
+	go func() {
+		done <- r.shutdown(ts.Config, tracker)
+	}()
+
+	// Regular connection is cut off, long-lived one is still being served
+	select {
+	case name := <-cutOff:
+		if name != "short" {
+			t.Fatalf("shutdown -> Expected the regular connection to be cut off first, Output : %s", name)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("shutdown -> Expected the regular connection to be cut off")
+	}
+
+	select {
+	case <-cutOff:
+		t.Fatalf("shutdown -> Expected the long-lived connection to get extra grace")
+	default:
+	}
+
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("shutdown -> Expected : <nil>, Output : %s", err)
+	}
+}
+
+func TestMarkLongLivedCleared(t *testing.T) {
+	tracker := newDrainTracker()
+	dc := &drainConn{}
+
+	handler := tracker.countRequests(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		MarkLongLived(req)
+		if atomic.LoadInt32(&dc.longLived) != 1 {
+			t.Errorf("MarkLongLived -> Expected the connection to be flagged")
+		}
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(drainConnKey.Set(req.Context(), dc)))
+
+	// The kept-alive connection goes back to the regular drain timeout
+	if atomic.LoadInt32(&dc.longLived) != 0 {
+		t.Fatalf("MarkLongLived -> Expected the flag to be cleared once the request is done")
+	}
+}
+
+func TestTrackGoroutine(t *testing.T) {
+	r := New()
+	sr := r.Subrouter("/jobs")
+
+	var finished int32
+
+	sr.POST("/", func(w http.ResponseWriter, req *http.Request) {
+		done := sr.TrackGoroutine()
+		go func() {
+			defer done()
+			time.Sleep(200 * time.Millisecond)
+			atomic.StoreInt32(&finished, 1)
+		}()
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	tracker := newDrainTracker()
+	ts := httptest.NewUnstartedServer(r)
+	ts.Config.ConnContext = tracker.connContext
+	ts.Config.ConnState = tracker.connState
+	ts.Start()
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/jobs", "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if err := r.shutdown(ts.Config, tracker); err != nil {
+		t.Fatal(err)
+	}
+
+	if atomic.LoadInt32(&finished) != 1 {
+		t.Fatalf("TrackGoroutine -> Expected shutdown to wait for the tracked goroutine")
+	}
+}
+
+func TestShutdownReport(t *testing.T) {
+	r := New()
+	r.GET("/", Home)
+
+	tracker := newDrainTracker()
+	ts := httptest.NewUnstartedServer(tracker.countRequests(r))
+	ts.Config.ConnContext = tracker.connContext
+	ts.Config.ConnState = tracker.connState
+	ts.Start()
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		res, err := http.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	var ran []int
+	report := r.gracefulShutdown(ts.Config, tracker,
+		func() { ran = append(ran, 1) },
+		func() { ran = append(ran, 2) },
+	)
+
+	if report.Requests != 3 {
+		t.Fatalf("ShutdownReport.Requests -> Expected : %d, Output : %d", 3, report.Requests)
+	}
+
+	if report.ShutdownFns != 2 || !reflect.DeepEqual(ran, []int{2, 1}) {
+		t.Fatalf("ShutdownReport.ShutdownFns -> Expected : 2 run in reverse order, Output : %d %v", report.ShutdownFns, ran)
+	}
+
+	if report.Duration <= 0 || report.Err != nil {
+		t.Fatalf("ShutdownReport -> Expected a duration and no error, Output : %s %v", report.Duration, report.Err)
+	}
+}
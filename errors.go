@@ -0,0 +1,108 @@
+package jett
+
+import (
+	"errors"
+	"log"
+	"net/http"
+)
+
+/* -------------------------- ERROR-RETURNING HANDLERS ------------------------- */
+
+// HandlerFuncE is an http handler that returns an error instead of writing it.
+// Returned errors are written by DefaultErrorHandler.
+//
+//	r.Handle(http.MethodPost, "/users", jett.HandlerFuncE(CreateUser))
+type HandlerFuncE func(w http.ResponseWriter, req *http.Request) error
+
+// Implement http.Handler interface
+func (fn HandlerFuncE) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := fn(w, req); err != nil {
+		DefaultErrorHandler(w, req, err)
+	}
+}
+
+// Writes internal server errors - the failures of the renderers (eg. JSON fails to marshal the data)
+// and the errors returned by a HandlerFuncE
+var internalErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+	if req != nil {
+		log.Printf("Internal Server Error - %s %s : %s", req.Method, req.URL.Path, err)
+	} else {
+		log.Printf("Internal Server Error : %s", err)
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// SetInternalErrorHandler replaces the handler called for internal server errors - when a renderer
+// (JSON, XML, HTML ...) fails or a HandlerFuncE returns an error, so that they match the app's
+// error format -
+//
+//	jett.SetInternalErrorHandler(func(w http.ResponseWriter, req *http.Request, err error) {
+//		log.Printf("%s : %s", middleware.GetRequestID(req.Context()), err)
+//		jett.JSONError(w, "internal server error", http.StatusInternalServerError)
+//	})
+//
+// req is nil when the failing renderer isn't given the request (eg. JSON, XML), it's set for
+// Render, SSE and HandlerFuncE errors.
+// The default logs the error and writes a plain text 500. Set it before serving requests.
+func SetInternalErrorHandler(fn func(w http.ResponseWriter, req *http.Request, err error)) {
+	internalErrorHandler = fn
+}
+
+// Called for internal server errors, req may be nil
+func internalError(w http.ResponseWriter, req *http.Request, err error) {
+	internalErrorHandler(w, req, err)
+}
+
+// FieldError describes why a single field of the request failed to bind
+type FieldError struct {
+	Field   string `json:"field" xml:"field"`
+	Message string `json:"message" xml:"message"`
+}
+
+// BindError is returned when a request fails to bind or validate.
+// It is written as 422 Unprocessable Entity along with the field errors.
+type BindError struct {
+	Fields []FieldError `json:"errors" xml:"errors"`
+}
+
+// Implement error interface
+func (e *BindError) Error() string {
+	if len(e.Fields) == 0 {
+		return "jett: bind failed"
+	}
+	return "jett: bind failed - " + e.Fields[0].Field + ": " + e.Fields[0].Message
+}
+
+// DefaultErrorHandler writes an error returned by a HandlerFuncE -
+// - *BindError -> 422 with the field errors as JSON
+// - any other error -> 500 Internal Server Error, written by the internal error handler (see SetInternalErrorHandler)
+func DefaultErrorHandler(w http.ResponseWriter, req *http.Request, err error) {
+	var bindErr *BindError
+	if errors.As(err, &bindErr) {
+		JSON(w, bindErr, http.StatusUnprocessableEntity)
+		return
+	}
+
+	internalError(w, req, err)
+}
+
+// ErrorResponse is the standard JSON error body written by JSONError -
+//
+//	{"error": {"code": 404, "message": "user not found"}}
+//
+// Embed it in your own struct to extend it with more fields.
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error" xml:"error"`
+}
+
+// ErrorDetail is the error described by an ErrorResponse
+type ErrorDetail struct {
+	Code    int    `json:"code" xml:"code"`
+	Message string `json:"message" xml:"message"`
+}
+
+// JSONError writes message as a standard ErrorResponse with the given status code.
+// Sets the Content-Type header to application/json
+func JSONError(w http.ResponseWriter, message string, status int) {
+	JSON(w, ErrorResponse{Error: ErrorDetail{Code: status, Message: message}}, status)
+}
@@ -0,0 +1,116 @@
+package jett
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestBindError(t *testing.T) {
+	r := New()
+
+	r.Handle(http.MethodPost, "/bind", HandlerFuncE(func(w http.ResponseWriter, req *http.Request) error {
+		return &BindError{
+			Fields: []FieldError{{Field: "name", Message: "is required"}},
+		}
+	}))
+	r.Handle(http.MethodPost, "/fail", HandlerFuncE(func(w http.ResponseWriter, req *http.Request) error {
+		return errors.New("something broke")
+	}))
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/bind", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("BindError -> Expected : %d, Output : %d", http.StatusUnprocessableEntity, res.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var bindErr BindError
+	json.Unmarshal(body, &bindErr)
+
+	expected := []FieldError{{Field: "name", Message: "is required"}}
+	if !reflect.DeepEqual(bindErr.Fields, expected) {
+		t.Fatalf("BindError -> Expected : %+v, Output : %s", expected, body)
+	}
+
+	res, err = http.Post(ts.URL+"/fail", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("DefaultErrorHandler -> Expected : %d, Output : %d", http.StatusInternalServerError, res.StatusCode)
+	}
+}
+
+func TestJSONError(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	JSONError(w, "user not found", http.StatusNotFound)
+
+	expected := `{"error":{"code":404,"message":"user not found"}}`
+	if w.Code != http.StatusNotFound || w.Body.String() != expected {
+		t.Fatalf("JSONError -> Expected : 404 %s, Output : %d %s", expected, w.Code, w.Body.String())
+	}
+
+	if output := w.Header().Get("Content-Type"); output != "application/json" {
+		t.Fatalf("JSONError Content-Type -> Expected : application/json, Output : %s", output)
+	}
+}
+
+func TestSetInternalErrorHandler(t *testing.T) {
+	defer SetInternalErrorHandler(internalErrorHandler)
+
+	var gotReq *http.Request
+	SetInternalErrorHandler(func(w http.ResponseWriter, req *http.Request, err error) {
+		gotReq = req
+		JSONError(w, "internal server error", http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+
+	renderers := map[string]struct {
+		render  func(w http.ResponseWriter)
+		withReq bool
+	}{
+		"JSON":                {func(w http.ResponseWriter) { JSON(w, make(chan int), 200) }, false},
+		"XML":                 {func(w http.ResponseWriter) { XML(w, make(chan int), 200) }, false},
+		"HTML":                {func(w http.ResponseWriter) { HTML(w, nil, "missing.html") }, false},
+		"Render":              {func(w http.ResponseWriter) { Render(w, req, make(chan int), 200) }, true},
+		"DefaultErrorHandler": {func(w http.ResponseWriter) { DefaultErrorHandler(w, req, errors.New("something broke")) }, true},
+		"SSE":                 {func(w http.ResponseWriter) { SSE(struct{ http.ResponseWriter }{w}, req, nil) }, true},
+	}
+
+	expected := `{"error":{"code":500,"message":"internal server error"}}`
+
+	for name, test := range renderers {
+		gotReq = nil
+
+		w := httptest.NewRecorder()
+		test.render(w)
+
+		if w.Code != http.StatusInternalServerError || w.Body.String() != expected {
+			t.Fatalf("SetInternalErrorHandler %s -> Expected : 500 %s, Output : %d %s", name, expected, w.Code, w.Body.String())
+		}
+
+		if test.withReq && gotReq != req {
+			t.Fatalf("SetInternalErrorHandler %s -> Expected the request to be passed to the handler", name)
+		}
+	}
+}
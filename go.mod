@@ -1,5 +1,8 @@
 module github.com/saurabh0719/jett
 
-go 1.7
+go 1.18
 
-require github.com/julienschmidt/httprouter v1.3.0
+require (
+	github.com/julienschmidt/httprouter v1.3.0
+	golang.org/x/text v0.22.0
+)
@@ -0,0 +1,68 @@
+package jett
+
+import (
+	"context"
+	"time"
+)
+
+/* -------------------------- LIVENESS CHECKS ------------------------- */
+
+// Liveness check that shuts the development server down once it fails repeatedly
+type livenessCheck struct {
+	check    func() error
+	failures int
+	interval time.Duration
+}
+
+// ShutdownOnUnhealthy runs check every interval while the development server is running
+// and gracefully shuts the server down after failures consecutive failures, so that
+// an orchestrator can restart it -
+//
+//	r.ShutdownOnUnhealthy(db.Ping, 3, 10*time.Second)
+//
+// Panics if failures or interval isn't positive.
+func (r *Router) ShutdownOnUnhealthy(check func() error, failures int, interval time.Duration) {
+	if failures <= 0 {
+		panic("jett: ShutdownOnUnhealthy failures must be positive")
+	}
+	if interval <= 0 {
+		panic("jett: ShutdownOnUnhealthy interval must be positive")
+	}
+
+	r.livenessChecks = append(r.livenessChecks, livenessCheck{
+		check:    check,
+		failures: failures,
+		interval: interval,
+	})
+}
+
+// Runs the check until ctx is done, sends the last error on unhealthy
+// once it has failed enough times in a row
+func (lc livenessCheck) watch(ctx context.Context, unhealthy chan<- error) {
+	ticker := time.NewTicker(lc.interval)
+	defer ticker.Stop()
+
+	failed := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := lc.check()
+		if err == nil {
+			failed = 0
+			continue
+		}
+
+		failed++
+		if failed >= lc.failures {
+			select {
+			case unhealthy <- err:
+			default:
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package jett
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShutdownOnUnhealthy(t *testing.T) {
+	r := New()
+	r.GET("/", Home)
+
+	var healthy int32 = 1
+	var calls int32
+	r.ShutdownOnUnhealthy(func() error {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&healthy) == 1 {
+			return nil
+		}
+		return errors.New("database unreachable")
+	}, 3, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var shutdownFnRan int32
+	stopped := make(chan struct{})
+	go func() {
+		r.RunWithContext(ctx, "127.0.0.1:0", func() {
+			atomic.StoreInt32(&shutdownFnRan, 1)
+		})
+		close(stopped)
+	}()
+
+	// Keeps running while the check passes
+	select {
+	case <-stopped:
+		t.Fatalf("ShutdownOnUnhealthy -> Expected the server to keep running while healthy")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	atomic.StoreInt32(&healthy, 0)
+	failingFrom := atomic.LoadInt32(&calls)
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("ShutdownOnUnhealthy -> Expected the server to shut down after 3 failures")
+	}
+
+	if failed := atomic.LoadInt32(&calls) - failingFrom; failed < 3 {
+		t.Fatalf("ShutdownOnUnhealthy -> Expected at least 3 failed checks, Output : %d", failed)
+	}
+
+	if atomic.LoadInt32(&shutdownFnRan) != 1 {
+		t.Fatalf("ShutdownOnUnhealthy -> Expected a graceful shutdown running the shutdown functions")
+	}
+}
+
+func TestShutdownOnUnhealthyInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		interval time.Duration
+	}{
+		{"zero failures", 0, time.Second},
+		{"negative failures", -1, time.Second},
+		{"zero interval", 3, 0},
+		{"negative interval", 3, -time.Second},
+	}
+
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("ShutdownOnUnhealthy %s -> Expected a panic", test.name)
+				}
+			}()
+
+			New().ShutdownOnUnhealthy(func() error { return nil }, test.failures, test.interval)
+		}()
+	}
+}
@@ -3,7 +3,7 @@
 // Jett builds a layer on top of HttpRouter to enable subrouting
 // and flexible addition of middleware at any level - root, subrouter or a specific route!
 //
-// Built for Go 1.7 & above.
+// Built for Go 1.18 & above.
 //
 // Example :
 // 	package main
@@ -49,15 +49,27 @@ package jett
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"github.com/julienschmidt/httprouter"
 	"html/template"
+	"io"
+	"io/fs"
+	"io/ioutil"
 	"log"
+	"mime"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
@@ -93,10 +105,46 @@ type Router struct {
 	// middleware stack -> List of middleware associated with the router
 	middleware []func(http.Handler) http.Handler
 
+	// middlewareNames -> Names of the middleware in the stack (same order), "" if registered with Use
+	middlewareNames []string
+
+	// middlewareVersion -> Bumped whenever the middleware stack changes,
+	// invalidates the handler chains cached by the router's routes
+	middlewareVersion uint64
+	mu                sync.RWMutex
+
+	// pre-routing middleware stack -> Wraps the entire router, runs before a route is matched
+	pre []func(http.Handler) http.Handler
+
+	// preVersion -> Bumped whenever the pre-routing stack changes,
+	// invalidates preCached (*composedChain, the router wrapped with the pre-routing stack)
+	preVersion uint64
+	preCached  atomic.Value
+
 	// pathPrefix -> Contains total path of that router,
 	// which is then prefixed with every subrouter.
 	// default - '/' (root)
 	pathPrefix string
+
+	// registry -> Routes registered on the router, shared with subrouters
+	registry *routeRegistry
+
+	// debugOut -> Where goroutine stacks are dumped on SIGUSR1, nil to disable
+	debugOut io.Writer
+
+	// readyChecks -> Readiness gates that must pass before the server starts serving
+	readyChecks []func() error
+
+	// livenessChecks -> Checks that shut the server down once they fail repeatedly
+	livenessChecks []livenessCheck
+
+	// background -> Goroutines spawned by handlers that shutdown waits for, shared with subrouters
+	background *sync.WaitGroup
+
+	// drainTimeout, longLivedTimeout -> How long regular and long-lived connections
+	// are given to finish during graceful shutdown
+	drainTimeout     time.Duration
+	longLivedTimeout time.Duration
 }
 
 // Create a new instance of the Jett's Router
@@ -112,7 +160,11 @@ func New() *Router {
 	return &Router{
 		router: r,
 		// Root path prefix
-		pathPrefix: "/",
+		pathPrefix:       "/",
+		registry:         &routeRegistry{},
+		background:       &sync.WaitGroup{},
+		drainTimeout:     5 * time.Second,
+		longLivedTimeout: 10 * time.Second,
 	}
 }
 
@@ -122,8 +174,72 @@ func New() *Router {
 // To use built-in essential middleware,
 //	 import "github.com/saurabh0719/jett/middleware"
 // Read https://github.com/saurabh0719/jett#middleware for further details.
+//
+// Middleware also applies to routes that were registered on the router before Use was called.
 func (r *Router) Use(middleware ...func(http.Handler) http.Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	r.middleware = append(r.middleware, middleware...)
+	r.middlewareNames = append(r.middlewareNames, make([]string, len(middleware))...)
+	atomic.AddUint64(&r.middlewareVersion, 1)
+}
+
+// Add a named middleware to the Router's middleware stack.
+// Works like Use, the name shows up in MiddlewareNames and lets subrouters drop
+// the middleware with Without -
+//
+//	r.UseNamed("auth", middleware.BasicAuth("admin", credentials))
+//	public := r.Subrouter("/public")
+//	public.Without("auth")
+func (r *Router) UseNamed(name string, middleware func(http.Handler) http.Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.middleware = append(r.middleware, middleware)
+	r.middlewareNames = append(r.middlewareNames, name)
+	atomic.AddUint64(&r.middlewareVersion, 1)
+}
+
+// Removes the named middleware (see UseNamed) from the Router's middleware stack,
+// eg. on a subrouter that shouldn't inherit some of the parent's middleware.
+// The parent router keeps its own stack.
+func (r *Router) Without(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remove := make(map[string]bool, len(names))
+	for _, name := range names {
+		remove[name] = true
+	}
+
+	// New slices, the parent router may share the old ones' backing arrays
+	var middleware []func(http.Handler) http.Handler
+	var middlewareNames []string
+
+	for i, name := range r.middlewareNames {
+		if name != "" && remove[name] {
+			continue
+		}
+		middleware = append(middleware, r.middleware[i])
+		middlewareNames = append(middlewareNames, name)
+	}
+
+	r.middleware, r.middlewareNames = middleware, middlewareNames
+	atomic.AddUint64(&r.middlewareVersion, 1)
+}
+
+// Add a middleware that runs for every request before routing.
+// Unlike Use, Pre middleware wraps the entire router so it also covers paths
+// that don't match any route (NotFound), eg. for geo-blocking or bot detection.
+//
+// Pre middleware belongs to the router being served, call it on the root router.
+func (r *Router) Pre(middleware ...func(http.Handler) http.Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pre = append(r.pre, middleware...)
+	atomic.AddUint64(&r.preVersion, 1)
 }
 
 // Create a new subrouter.
@@ -131,9 +247,12 @@ func (r *Router) Use(middleware ...func(http.Handler) http.Handler) {
 func (r *Router) Subrouter(path string) *Router {
 
 	sr := &Router{
-		router:     r.router,
-		middleware: r.middleware,
-		pathPrefix: r.getFullPath(path),
+		router:          r.router,
+		middleware:      r.Middleware(),
+		middlewareNames: r.MiddlewareNames(),
+		pathPrefix:      r.getFullPath(path),
+		registry:        r.registry,
+		background:      r.background,
 	}
 
 	return sr
@@ -144,21 +263,88 @@ func (r *Router) NotFound(handlerFn http.HandlerFunc) {
 	r.router.NotFound = http.HandlerFunc(handlerFn)
 }
 
-// creates an http.Handler for the router + middleware stack
+// Assigns a HandlerFunc as the 405 Method Not Allowed handler, called when a path matches
+// routes for other methods only. The Allow header listing the permitted methods is set before it's called.
+//
+// Jett responds with 404 in that case by default, calling MethodNotAllowed switches it to 405.
+// A nil handlerFn responds with a plain text 405.
+func (r *Router) MethodNotAllowed(handlerFn http.HandlerFunc) {
+	r.router.HandleMethodNotAllowed = true
+	if handlerFn != nil {
+		r.router.MethodNotAllowed = handlerFn
+	}
+}
+
+// Toggles redirecting requests with a trailing slash to the route without it (and vice versa),
+// eg. /users/ -> /users. Enabled by default, turn it off for APIs where /users/ is a distinct resource.
+// GET requests are redirected with 301 Moved Permanently and other methods with 307 Temporary Redirect.
+func (r *Router) RedirectTrailingSlash(enabled bool) {
+	r.router.RedirectTrailingSlash = enabled
+}
+
+// Toggles redirecting requests to a cleaned up, case-insensitive match of the path
+// if there's no route for it, eg. /../USERS -> /users. Enabled by default.
+func (r *Router) RedirectFixedPath(enabled bool) {
+	r.router.RedirectFixedPath = enabled
+}
+
+// Toggles automatic replies to OPTIONS requests (enabled by default). Paths with routes
+// but no OPTIONS route of their own are answered with an Allow header listing their methods.
+// Disabled, such OPTIONS requests get the NotFound (or MethodNotAllowed) handler.
+func (r *Router) HandleOPTIONS(enabled bool) {
+	r.router.HandleOPTIONS = enabled
+}
+
+// Assigns a HandlerFunc called on automatic OPTIONS replies (see HandleOPTIONS),
+// eg. to answer CORS preflight requests in a single place.
+// The Allow header is set before it's called. Like NotFound, the middleware stack
+// added with Use doesn't apply, only Pre middleware does.
+func (r *Router) GlobalOPTIONS(handlerFn http.HandlerFunc) {
+	r.router.GlobalOPTIONS = handlerFn
+}
+
+// creates an http.Handler for the router + pre-routing middleware stack.
+// The handler is cached and only composed again once the stack has changed (Router.Pre).
 func (r *Router) Handler() http.Handler {
-	var handler http.Handler = r.router
-	return handler
+	version := atomic.LoadUint64(&r.preVersion)
+	if c, ok := r.preCached.Load().(*composedChain); ok && c.version == version {
+		return c.handler
+	}
+
+	r.mu.RLock()
+	c := &composedChain{
+		version: r.preVersion,
+		handler: r.router,
+	}
+	for i := len(r.pre) - 1; i >= 0; i-- {
+		c.handler = r.pre[i](c.handler)
+	}
+	r.mu.RUnlock()
+
+	r.preCached.Store(c)
+	return c.handler
 }
 
 // Implement http.Handler interface
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	handler := r.Handler()
-	handler.ServeHTTP(w, req)
+	r.Handler().ServeHTTP(w, req)
 }
 
 // Middleware returns a slice ([]func(http.Handler) http.Handler) of the middleware stack for the router
 func (r *Router) Middleware() []func(http.Handler) http.Handler {
-	return r.middleware
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]func(http.Handler) http.Handler(nil), r.middleware...)
+}
+
+// MiddlewareNames returns the names of the router's middleware stack, in the same order
+// as Middleware. Middleware added with Use (instead of UseNamed) has an empty name.
+func (r *Router) MiddlewareNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string(nil), r.middlewareNames...)
 }
 
 // Serve Static files from a directory.
@@ -181,6 +367,30 @@ func (r *Router) ServeFiles(path string, root http.FileSystem) {
 	r.router.ServeFiles(path, root)
 }
 
+// Mounts an endpoint at path that collects Content-Security-Policy violation reports
+// (see middleware.CSPReportOnly). Each report body is passed to handlerFn as is.
+//
+// Only POST requests with Content-Type application/csp-report (or application/json)
+// are accepted, reports are limited to 64KB.
+func (r *Router) MountCSPReports(path string, handlerFn func(report []byte)) *Route {
+	return r.POST(path, func(w http.ResponseWriter, req *http.Request) {
+		mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if mediaType != "application/csp-report" && mediaType != "application/json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		report, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, 64<<10))
+		if err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		handlerFn(report)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
 // Retrieves full path of the current handler from root
 func (r *Router) getFullPath(subPath string) string {
 	fullPath := r.pathPrefix + subPath
@@ -191,8 +401,12 @@ func (r *Router) getFullPath(subPath string) string {
 
 /* -------------------------- REGISTER HTTP METHOD HANDLERS ------------------------- */
 
-// Register the path and method to the given handler. Also applies the middleware to the Handler
-func (r *Router) Handle(method, path string, handler http.Handler, middleware ...func(http.Handler) http.Handler) {
+// Register the path and method to the given handler. Also applies the middleware to the Handler.
+// Returns the registered Route to optionally attach metadata to it.
+//
+// The Router's middleware stack is applied lazily, the composed chain is cached
+// by the route and only rebuilt when the stack changes.
+func (r *Router) Handle(method, path string, handler http.Handler, middleware ...func(http.Handler) http.Handler) *Route {
 
 	// full path from root
 	fullPath := r.getFullPath(path)
@@ -202,48 +416,51 @@ func (r *Router) Handle(method, path string, handler http.Handler, middleware ..
 		handler = middleware[i](handler)
 	}
 
-	// apple rest of the middleware stack from the Router
-	for i := len(r.middleware) - 1; i >= 0; i-- {
-		handler = r.middleware[i](handler)
-	}
-
 	// insert into httprouter
-	r.router.Handler(method, fullPath, handler)
+	rt := r.registry.add(method, fullPath, handler)
+	rt.router = r
+	rt.routeMiddleware = len(middleware)
+	r.router.Handler(method, fullPath, http.HandlerFunc(rt.serveHTTP))
+
+	// compose the chain now rather than on the first request
+	rt.chain()
+
+	return rt
 }
 
 // Assigns a HandlerFunc to the GET method for the given path. Route-specific middleware can be added as well.
-func (r *Router) GET(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) {
-	r.Handle(http.MethodGet, path, http.HandlerFunc(handlerFn), middleware...)
+func (r *Router) GET(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.Handle(http.MethodGet, path, http.HandlerFunc(handlerFn), middleware...)
 }
 
 // Assigns a HandlerFunc to the HEAD method for the given path. Route-specific middleware can be added as well.
-func (r *Router) HEAD(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) {
-	r.Handle(http.MethodHead, path, http.HandlerFunc(handlerFn), middleware...)
+func (r *Router) HEAD(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.Handle(http.MethodHead, path, http.HandlerFunc(handlerFn), middleware...)
 }
 
 // Assigns a HandlerFunc to the OPTIONS method for the given path. Route-specific middleware can be added as well.
-func (r *Router) OPTIONS(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) {
-	r.Handle(http.MethodOptions, path, http.HandlerFunc(handlerFn), middleware...)
+func (r *Router) OPTIONS(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.Handle(http.MethodOptions, path, http.HandlerFunc(handlerFn), middleware...)
 }
 
 // Assigns a HandlerFunc to the POST method for the given path. Route-specific middleware can be added as well.
-func (r *Router) POST(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) {
-	r.Handle(http.MethodPost, path, http.HandlerFunc(handlerFn), middleware...)
+func (r *Router) POST(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.Handle(http.MethodPost, path, http.HandlerFunc(handlerFn), middleware...)
 }
 
 // Assigns a HandlerFunc to the PUT method for the given path. Route-specific middleware can be added as well.
-func (r *Router) PUT(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) {
-	r.Handle(http.MethodPut, path, http.HandlerFunc(handlerFn), middleware...)
+func (r *Router) PUT(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.Handle(http.MethodPut, path, http.HandlerFunc(handlerFn), middleware...)
 }
 
 // Assigns a HandlerFunc to the PATCH method for the given path. Route-specific middleware can be added as well.
-func (r *Router) PATCH(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) {
-	r.Handle(http.MethodPatch, path, http.HandlerFunc(handlerFn), middleware...)
+func (r *Router) PATCH(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.Handle(http.MethodPatch, path, http.HandlerFunc(handlerFn), middleware...)
 }
 
 // Assigns a HandlerFunc to the DELETE method for the given path. Route-specific middleware can be added as well.
-func (r *Router) DELETE(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) {
-	r.Handle(http.MethodDelete, path, http.HandlerFunc(handlerFn), middleware...)
+func (r *Router) DELETE(path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.Handle(http.MethodDelete, path, http.HandlerFunc(handlerFn), middleware...)
 }
 
 // Assigns a HandlerFunc to the GET, HEAD, OPTIONS, POST, PUT, PATCH & DELETE method for the given path.
@@ -254,6 +471,107 @@ func (r *Router) Any(path string, handlerFn http.HandlerFunc, middleware ...func
 	}
 }
 
+// Mounts an arbitrary http.Handler (eg. a pprof mux or a GraphQL server) under prefix.
+// Requests for every method above ^ under prefix pass through the middleware stack and are
+// handed to handler with the prefix stripped, so it sees paths relative to the mount -
+//
+//	r.Mount("/admin", adminHandler) // /admin/stats is served as /stats
+//
+// The prefix is registered as a catch-all, so no other routes can be registered under it.
+func (r *Router) Mount(prefix string, handler http.Handler, middleware ...func(http.Handler) http.Handler) {
+	fullPrefix := strings.TrimSuffix(r.getFullPath(prefix), "/")
+	handler = http.StripPrefix(fullPrefix, handler)
+
+	for _, method := range httpMethods {
+		r.Handle(method, strings.TrimSuffix(prefix, "/")+"/*mountpath", handler, middleware...)
+	}
+}
+
+// Mounts an existing http.ServeMux under prefix to migrate from net/http incrementally.
+// Works like Mount, the mux's patterns are written relative to the mount -
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("/users", legacyUsers)
+//
+//	r.HandleMux("/legacy", mux) // serves /legacy/users
+func (r *Router) HandleMux(prefix string, mux *http.ServeMux, middleware ...func(http.Handler) http.Handler) {
+	r.Mount(prefix, mux, middleware...)
+}
+
+/* -------------------------- FEATURE FLAGGED ROUTES ------------------------- */
+
+//
+// Routes that are only active while a feature flag is on, eg. for dark-launched endpoints.
+// The flag is checked on every request and the route responds with the router's
+// NotFound handler (404) while it's off.
+//
+//	var enabled int32
+//	r.GETIf(func() bool { return atomic.LoadInt32(&enabled) == 1 }, "/beta", Beta)
+//
+
+// Register the path and method to the given handler, active only while flag returns true.
+// Also applies the middleware to the Handler.
+func (r *Router) HandleIf(flag func() bool, method, path string, handler http.Handler, middleware ...func(http.Handler) http.Handler) *Route {
+	rt := r.Handle(method, path, handler, middleware...)
+	rt.enabled = flag
+	rt.notFound = r.serveNotFound
+	return rt
+}
+
+// Assigns a HandlerFunc to the GET method for the given path, active only while flag returns true.
+func (r *Router) GETIf(flag func() bool, path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.HandleIf(flag, http.MethodGet, path, http.HandlerFunc(handlerFn), middleware...)
+}
+
+// Assigns a HandlerFunc to the HEAD method for the given path, active only while flag returns true.
+func (r *Router) HEADIf(flag func() bool, path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.HandleIf(flag, http.MethodHead, path, http.HandlerFunc(handlerFn), middleware...)
+}
+
+// Assigns a HandlerFunc to the OPTIONS method for the given path, active only while flag returns true.
+func (r *Router) OPTIONSIf(flag func() bool, path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.HandleIf(flag, http.MethodOptions, path, http.HandlerFunc(handlerFn), middleware...)
+}
+
+// Assigns a HandlerFunc to the POST method for the given path, active only while flag returns true.
+func (r *Router) POSTIf(flag func() bool, path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.HandleIf(flag, http.MethodPost, path, http.HandlerFunc(handlerFn), middleware...)
+}
+
+// Assigns a HandlerFunc to the PUT method for the given path, active only while flag returns true.
+func (r *Router) PUTIf(flag func() bool, path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.HandleIf(flag, http.MethodPut, path, http.HandlerFunc(handlerFn), middleware...)
+}
+
+// Assigns a HandlerFunc to the PATCH method for the given path, active only while flag returns true.
+func (r *Router) PATCHIf(flag func() bool, path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.HandleIf(flag, http.MethodPatch, path, http.HandlerFunc(handlerFn), middleware...)
+}
+
+// Assigns a HandlerFunc to the DELETE method for the given path, active only while flag returns true.
+func (r *Router) DELETEIf(flag func() bool, path string, handlerFn http.HandlerFunc, middleware ...func(http.Handler) http.Handler) *Route {
+	return r.HandleIf(flag, http.MethodDelete, path, http.HandlerFunc(handlerFn), middleware...)
+}
+
+// Responds with the router's NotFound handler, http.NotFound if none is assigned
+func (r *Router) serveNotFound(w http.ResponseWriter, req *http.Request) {
+	if r.router.NotFound != nil {
+		r.router.NotFound.ServeHTTP(w, req)
+		return
+	}
+	http.NotFound(w, req)
+}
+
+// Responds with the NotFound handler of the router the request was routed by,
+// http.NotFound if the request didn't go through a router
+func notFound(w http.ResponseWriter, req *http.Request) {
+	if r, ok := routerKey.Get(req.Context()); ok {
+		r.serveNotFound(w, req)
+		return
+	}
+	http.NotFound(w, req)
+}
+
 /* -------------------------- GET PARAMS  ------------------------- */
 
 // Helper function to extract URL params from request Context()
@@ -276,8 +594,84 @@ func URLParams(req *http.Request) map[string]string {
 // Eg - /?one=true,false&two=true
 //
 // Result - {"two" : ["true"], "one": ["true, "false"]}
+//
+// Bracket notation (jQuery/Rails style clients) is collapsed into a slice under the base key.
+// Indexed values are ordered by their index.
+//
+// Eg - /?filter[]=a&filter[]=b OR /?filter[1]=b&filter[0]=a
+//
+// Result - {"filter" : ["a", "b"]}
 func QueryParams(req *http.Request) map[string][]string {
-	return req.URL.Query()
+	query := req.URL.Query()
+	params := make(map[string][]string, len(query))
+
+	// Indexed values are collected by base key and ordered once all keys are seen
+	type indexedValue struct {
+		index  int
+		values []string
+	}
+	indexed := make(map[string][]indexedValue)
+
+	for key, values := range query {
+		base, index, ok := parseBracketKey(key)
+		switch {
+		case !ok:
+			params[key] = append(params[key], values...)
+		case index < 0:
+			params[base] = append(params[base], values...)
+		default:
+			indexed[base] = append(indexed[base], indexedValue{index, values})
+		}
+	}
+
+	for base, items := range indexed {
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].index < items[j].index
+		})
+		for _, item := range items {
+			params[base] = append(params[base], item.values...)
+		}
+	}
+
+	return params
+}
+
+// Parses keys of the form base[] and base[n].
+// Returns the base key and the index (-1 for base[]), ok is false for any other key.
+func parseBracketKey(key string) (base string, index int, ok bool) {
+	open := strings.IndexByte(key, '[')
+	if open <= 0 || key[len(key)-1] != ']' {
+		return key, 0, false
+	}
+
+	base, inner := key[:open], key[open+1:len(key)-1]
+	if inner == "" {
+		return base, -1, true
+	}
+
+	index, err := strconv.Atoi(inner)
+	if err != nil || index < 0 {
+		return key, 0, false
+	}
+
+	return base, index, true
+}
+
+// Reports whether the client sent `Expect: 100-continue` and is waiting
+// for the server before sending the request body.
+//
+// net/http replies with "100 Continue" on the first read of req.Body, so a handler
+// can validate headers (auth, Content-Length etc.) and respond with an error
+// without reading the body - the client then never uploads it.
+//
+//	if jett.ExpectsContinue(req) && req.ContentLength > maxUpload {
+//		jett.Text(w, "Upload too large", http.StatusRequestEntityTooLarge)
+//		return
+//	}
+//	// Reading the body sends "100 Continue"
+//	io.Copy(dst, req.Body)
+func ExpectsContinue(req *http.Request) bool {
+	return req.ProtoAtLeast(1, 1) && strings.EqualFold(req.Header.Get("Expect"), "100-continue")
 }
 
 /* -------------------------- DEVELOPMENT SERVER & Run Fns------------------------- */
@@ -298,16 +692,32 @@ func (r *Router) runServer(ctx context.Context, address, certFile, keyFile strin
 		isTLS = false
 	}
 
+	// Fail fast if the app isn't ready to accept traffic
+	if err := r.checkReady(); err != nil {
+		log.Fatalf("Error: readiness check failed: %s\n", err)
+	}
+
+	// Keeps track of open connections to drain them during shutdown
+	tracker := newDrainTracker()
+
 	// New http server
 	server := &http.Server{
-		Addr:    address,
-		Handler: r,
+		Addr:        address,
+		Handler:     tracker.countRequests(r),
+		ConnContext: tracker.connContext,
+		ConnState:   tracker.connState,
 	}
 
 	// Notify stopServer channel with any of the below mentioned Signals
 	stopServer := make(chan os.Signal, 1)
 	signal.Notify(stopServer, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
+	// Dump goroutine stacks on SIGUSR1 if enabled
+	if r.debugOut != nil {
+		stopDebug := notifyDebugSignals(r.debugOut)
+		defer stopDebug()
+	}
+
 	// Run Server
 	go func() {
 		if isTLS {
@@ -326,44 +736,55 @@ func (r *Router) runServer(ctx context.Context, address, certFile, keyFile strin
 
 	fmt.Printf("Running Jett Server v%s, address -> %s\n\n", Version, address)
 
-	// Stop the server on signal notif or when parent ctx cancels
+	// Watch the liveness checks while the server is running
+	unhealthy := make(chan error, 1)
+	livenessCtx, stopLiveness := context.WithCancel(context.Background())
+	for _, lc := range r.livenessChecks {
+		go lc.watch(livenessCtx, unhealthy)
+	}
+
+	// Stop the server on signal notif, when parent ctx cancels or a liveness check keeps failing
 	select {
 	case <-stopServer:
 	case <-ctx.Done():
+	case err := <-unhealthy:
+		fmt.Printf("\n-> Liveness check failed: %s\n", err)
 	}
+	stopLiveness()
 
 	fmt.Printf("\n")
 	fmt.Println("-> Shutting down the server...")
-	defer fmt.Println("-> Server exited successfully.")
-
-	// context.Background() gives us an empty context
-	// set timeout to avoid keeping zombie conns alive
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 
-	// Defer the running of shutdown functions
-	defer func() {
-		totalFns := len(onShutdownFns)
-		if totalFns > 0 {
-			fmt.Println("-> Running shutdown functions...")
-		}
+	// Graceful shutdown, followed by the shutdown functions
+	report := r.gracefulShutdown(server, tracker, onShutdownFns...)
 
-		// Call each shutdown function one by one
-		for i, j := totalFns-1, 1; i >= 0; i, j = i-1, j+1 {
-			fmt.Println("-> ", j, " of ", totalFns)
-			onShutdownFns[i]()
-		}
+	// Stop receiving signals
+	signal.Stop(stopServer)
 
-		// Stop receiving signals
-		signal.Stop(stopServer)
-		// Cancel context after timeout
-		cancel()
-	}()
+	fmt.Println("-> Shutdown report:", report)
 
-	// Graceful shutdown
-	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("-> Server Shutdown Failed:%+v", err)
+	if report.Err != nil {
+		log.Fatalf("-> Server Shutdown Failed:%+v", report.Err)
 	}
 
+	fmt.Println("-> Server exited successfully.")
+}
+
+// Register readiness checks (eg. DB connected) that must pass before the development
+// server starts accepting traffic. Checks run in order when the server starts
+// and it exits on the first check that returns an error.
+func (r *Router) WaitForReady(checks ...func() error) {
+	r.readyChecks = append(r.readyChecks, checks...)
+}
+
+// Runs the readiness checks, returns the first error
+func (r *Router) checkReady() error {
+	for _, check := range r.readyChecks {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 //
@@ -386,6 +807,16 @@ func (r *Router) RunWithContext(ctx context.Context, address string, onShutdownF
 	r.runServer(ctx, address, "", "", onShutdownFns...)
 }
 
+// development server that handles graceful shutdown and dumps all goroutine stacks
+// to out whenever the process receives SIGUSR1, without stopping the server.
+// Useful to diagnose hangs -
+//	$ kill -USR1 <pid>
+// SIGUSR1 isn't available on windows, where this behaves like Run.
+func (r *Router) RunWithDebugSignals(address string, out io.Writer, onShutdownFns ...func()) {
+	r.debugOut = out
+	r.runServer(context.TODO(), address, "", "", onShutdownFns...)
+}
+
 // development server that runs with TLS and handles graceful shutdown.
 // onShutdownFns -> Cleanup functions to run during shutdown
 func (r *Router) RunTLS(address, certFile, keyFile string, onShutdownFns ...func()) {
@@ -411,33 +842,122 @@ func (r *Router) RunTLSWithContext(ctx context.Context, address, certFile, keyFi
 func JSON(w http.ResponseWriter, data interface{}, status int) {
 	// prepare JSON response
 	jsonData, err := json.Marshal(data)
+	writeJSON(w, jsonData, err, status)
+}
+
+// Indented JSON renderer, eg. for debugging APIs locally.
+// Works like JSON, with each element on a new line indented by indent
+func JSONIndent(w http.ResponseWriter, data interface{}, status int, indent string) {
+	jsonData, err := json.MarshalIndent(data, "", indent)
+	writeJSON(w, jsonData, err, status)
+}
+
+// Unescaped JSON renderer.
+// Works like JSON but leaves <, > and & as is instead of escaping them (eg. \u0026),
+// so URLs and query strings in the payload stay readable. Don't embed the output in HTML.
+func JSONUnescaped(w http.ResponseWriter, data interface{}, status int) {
+	jsonBuffer := new(bytes.Buffer)
+
+	encoder := json.NewEncoder(jsonBuffer)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(data)
+
+	// Encode adds a newline, drop it to match JSON
+	writeJSON(w, bytes.TrimSuffix(jsonBuffer.Bytes(), []byte("\n")), err, status)
+}
+
+// JSONP renderer for legacy clients.
+// Wraps the JSON as callback(<json>); and sets the Content-Type header to application/javascript.
+// Falls back to plain JSON if callback is empty, responds with 400 if it isn't a valid
+// function name (only letters, digits, _, $ and .)
+func JSONP(w http.ResponseWriter, data interface{}, callback string, status int) {
+	if callback == "" {
+		JSON(w, data, status)
+		return
+	}
+
+	if !isJSONPCallback(callback) {
+		http.Error(w, "Invalid JSONP callback", http.StatusBadRequest)
+		return
+	}
 
+	jsonData, err := json.Marshal(data)
 	if err != nil {
-		log.Print("Internal Server Error - JSON Response")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Print("Internal Server Error - JSONP Response")
+		internalError(w, nil, err)
 		return
 	}
 
-	// Set status and Content-Type
+	w.Header().Set("Content-Type", "application/javascript")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
 	w.WriteHeader(status)
+	io.WriteString(w, callback+"(")
+	w.Write(jsonData)
+	io.WriteString(w, ");")
+}
+
+// Streaming JSON renderer for large payloads.
+// Sets the status code and the Content-Type header to application/json, then encodes data
+// straight to the ResponseWriter instead of buffering it in memory (followed by a newline).
+//
+// The status has already been sent when encoding starts, so an encoding error can't be
+// turned into a 500 - it is logged and the client receives a truncated body.
+// Use JSON for data that might fail to marshal.
+func JSONStream(w http.ResponseWriter, data interface{}, status int) {
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("JSON Stream Response - encoding failed: %v", err)
+	}
+}
+
+// Pre-marshalled JSON renderer, eg. for cached or proxied payloads.
+// Writes raw as is without re-encoding it, so it must already be valid JSON.
+// Sets the Content-Type header to application/json
+func JSONBytes(w http.ResponseWriter, raw []byte, status int) {
+	writeJSON(w, raw, nil, status)
+}
+
+// Only allows [a-zA-Z0-9_$.]
+func isJSONPCallback(callback string) bool {
+	for _, c := range callback {
+		valid := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+			c == '_' || c == '$' || c == '.'
+		if !valid {
+			return false
+		}
+	}
+	return true
+}
+
+// Writes marshalled JSON, or a 500 if marshalling failed
+func writeJSON(w http.ResponseWriter, jsonData []byte, err error, status int) {
+	if err != nil {
+		log.Print("Internal Server Error - JSON Response")
+		internalError(w, nil, err)
+		return
+	}
+
+	// Set Content-Type and status
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
 	w.Write(jsonData)
 }
 
 // Plain Text renderer.
 // Sets the status code and the Content-Type header to text/plain
 func Text(w http.ResponseWriter, data string, status int) {
-	// Set status and Content-Type
-	w.WriteHeader(status)
+	// Set Content-Type and status, headers are sent with WriteHeader
 	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(status)
 
-	// Write plain text response
-	_, err := fmt.Fprintf(w, data)
+	// Write plain text response verbatim, data is not a format string
+	// The status has already been sent, so a failed write can only be logged
+	_, err := io.WriteString(w, data)
 
 	if err != nil {
-		log.Print("Internal Server Error - Plain Text Response")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		log.Printf("Plain Text Response - write failed: %v", err)
 	}
 }
 
@@ -449,13 +969,13 @@ func XML(w http.ResponseWriter, data interface{}, status int) {
 
 	if err != nil {
 		log.Print("Internal Server Error - XML Response")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		internalError(w, nil, err)
 		return
 	}
 
-	// Set status and Content-Type
-	w.WriteHeader(status)
+	// Set Content-Type and status, headers are sent with WriteHeader
 	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
 	w.Write(xmlData)
 }
 
@@ -467,7 +987,7 @@ func HTML(w http.ResponseWriter, data interface{}, htmlFiles ...string) {
 	// Parse all the html files passed
 	t, err := template.ParseFiles(htmlFiles...)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		internalError(w, nil, err)
 		return
 	}
 
@@ -476,17 +996,471 @@ func HTML(w http.ResponseWriter, data interface{}, htmlFiles ...string) {
 
 	// pass data (or nil) for the template
 	if err := t.Execute(htmlBuffer, data); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		internalError(w, nil, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+
+	// Write the rendered html verbatim
+	// The status has already been sent, so a failed write can only be logged
+	_, err = htmlBuffer.WriteTo(w)
+	if err != nil {
+		log.Printf("HTML Template Response - write failed: %v", err)
+	}
+
+}
+
+// Named HTML template renderer, for layouts and partials.
+// Works like HTML but executes the template with the given name, eg. a {{define "content"}} block
+// or the base name of one of the files -
+//
+//	jett.HTMLTemplate(w, "layout.html", data, "layout.html", "index.html")
+func HTMLTemplate(w http.ResponseWriter, name string, data interface{}, htmlFiles ...string) {
+	t, err := template.ParseFiles(htmlFiles...)
+	if err != nil {
+		internalError(w, nil, err)
+		return
+	}
+
+	htmlBuffer := new(bytes.Buffer)
+	if err := t.ExecuteTemplate(htmlBuffer, name, data); err != nil {
+		internalError(w, nil, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if _, err := htmlBuffer.WriteTo(w); err != nil {
+		log.Printf("HTML Template Response - write failed: %v", err)
+	}
+}
+
+// HTML template renderer for templates embedded in the binary (embed.FS) or any other fs.FS.
+// Works like HTML, with the files matched by the glob patterns (in order of parent -> children) -
+//
+//	//go:embed templates
+//	var templates embed.FS
+//
+//	jett.HTMLFS(w, templates, data, "templates/layout.html", "templates/index.html")
+func HTMLFS(w http.ResponseWriter, fsys fs.FS, data interface{}, patterns ...string) {
+	t, err := template.ParseFS(fsys, patterns...)
+	if err != nil {
+		internalError(w, nil, err)
+		return
+	}
+
+	htmlBuffer := new(bytes.Buffer)
+	if err := t.Execute(htmlBuffer, data); err != nil {
+		internalError(w, nil, err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "text/html")
+	if _, err := htmlBuffer.WriteTo(w); err != nil {
+		log.Printf("HTML Template Response - write failed: %v", err)
+	}
+}
+
+// CSV renderer for export endpoints.
+// Sets the Content-Type header to text/csv, marks the response as an attachment
+// and writes the headers row (if any) followed by the rows, quoted as needed.
+func CSV(w http.ResponseWriter, status int, rows [][]string, headers []string) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment")
+	w.WriteHeader(status)
+
+	csvWriter := csv.NewWriter(w)
+	if headers != nil {
+		csvWriter.Write(headers)
+	}
+	csvWriter.WriteAll(rows)
+
+	// Status is already sent, nothing left to do but log
+	if err := csvWriter.Error(); err != nil {
+		log.Print("Internal Server Error - CSV Response : ", err)
+	}
+}
+
+// CSV renderer for report endpoints that need a file name.
+// Sets the Content-Type header to text/csv and, if filename isn't empty, marks the response
+// as an attachment with that name. Rows are written straight to the ResponseWriter.
+//
+// The status is already sent when rows are written, so write errors (eg. the client went away)
+// are returned to the caller instead of being turned into a 500.
+func CSVFile(w http.ResponseWriter, records [][]string, filename string, status int) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if filename != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+	w.WriteHeader(status)
+
+	csvWriter := csv.NewWriter(w)
+	for _, record := range records {
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+
+	// Flush whatever is still buffered by the csv.Writer
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// Streaming CSV renderer for large exports.
+// Sets the same headers as CSV and writes every row received on rowCh
+// until the channel is closed, flushing after each row.
+func CSVStream(w http.ResponseWriter, status int, rowCh <-chan []string) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment")
+	w.WriteHeader(status)
+
+	flusher, _ := w.(http.Flusher)
+	csvWriter := csv.NewWriter(w)
+
+	for row := range rowCh {
+		csvWriter.Write(row)
+		csvWriter.Flush()
+
+		if err := csvWriter.Error(); err != nil {
+			log.Print("Internal Server Error - CSV Stream Response : ", err)
+			// Drain the channel so the producer doesn't block forever
+			for range rowCh {
+			}
+			return
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// Raw data renderer, eg. for images or PDFs generated in memory.
+// Sets the status code and the given Content-Type header
+func Blob(w http.ResponseWriter, contentType string, data []byte, status int) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Blob Response - write failed: %v", err)
+	}
+}
+
+// Status-only responder, eg. 204 No Content for DELETE or PUT handlers.
+// Writes the status code without a body, removing any Content-Type set so far
+// (eg. by Route.Produces).
+func Status(w http.ResponseWriter, status int) {
+	w.Header().Del("Content-Type")
+	w.WriteHeader(status)
+}
+
+// HTTP/2 server push helper.
+// Pushes target (eg. "/static/styles.css") to the client before the response that needs it.
+// Returns http.ErrNotSupported when the connection doesn't support push (eg. HTTP/1.1).
+func Push(w http.ResponseWriter, target string, opts *http.PushOptions) error {
+	pusher, ok := w.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+	return pusher.Push(target, opts)
+}
+
+// ExposeHeader lists a response header (eg. X-Total-Count for pagination) in
+// Access-Control-Expose-Headers so that cross-origin clients can read it.
+// Must be called before the header is written. Names already listed are skipped.
+func ExposeHeader(w http.ResponseWriter, name string) {
+	for _, value := range w.Header().Values("Access-Control-Expose-Headers") {
+		for _, exposed := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(exposed), name) {
+				return
+			}
+		}
+	}
+
+	w.Header().Add("Access-Control-Expose-Headers", name)
+}
+
+// Redirect helper.
+// Redirects the request to url with the given 3xx status code. Any other status is logged
+// and replaced with 302 Found.
+func Redirect(w http.ResponseWriter, req *http.Request, url string, status int) {
+	if status < 300 || status > 399 {
+		log.Printf("Redirect - invalid status %d, defaulting to %d", status, http.StatusFound)
+		status = http.StatusFound
+	}
+
+	http.Redirect(w, req, url, status)
+}
+
+// File download helper.
+// Serves the file at filePath as an attachment named fileName (derived from filePath if empty),
+// so browsers download it instead of displaying it. The Content-Type is detected from the extension.
+// Served with http.ServeContent, so range requests and If-Modified-Since work.
+//
+// Responds with the router's NotFound handler (404) if the file doesn't exist.
+func Download(w http.ResponseWriter, req *http.Request, filePath, fileName string) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		notFound(w, req)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		notFound(w, req)
+		return
+	}
+
+	if fileName == "" {
+		fileName = filepath.Base(filePath)
+	}
+
+	if contentType := mime.TypeByExtension(filepath.Ext(fileName)); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
 
-	// Write plain text response
-	_, err = fmt.Fprintf(w, htmlBuffer.String())
+	http.ServeContent(w, req, fileName, info.ModTime(), f)
+}
+
+// ErrStreamingUnsupported is passed to the internal error handler (see SetInternalErrorHandler)
+// when SSE is given a ResponseWriter that doesn't implement http.Flusher
+var ErrStreamingUnsupported = errors.New("jett: streaming unsupported")
+
+// Server-Sent Events renderer for live updates.
+// Sets the Content-Type header to text/event-stream and writes every event received on events
+// as a data message, flushing after each. Returns when the channel is closed or the client
+// goes away (req.Context() is cancelled).
+//
+// The ResponseWriter must implement http.Flusher (net/http's does, middleware wrapping it
+// must pass Flush through), responds with 500 otherwise.
+// Use MarkLongLived to give the connection more time during graceful shutdown.
+func SSE(w http.ResponseWriter, req *http.Request, events <-chan string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		internalError(w, req, ErrStreamingUnsupported)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			// Every line of a multi-line event needs its own data field
+			message := "data: " + strings.ReplaceAll(event, "\n", "\ndata: ") + "\n\n"
+			if _, err := io.WriteString(w, message); err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+// Content negotiation renderer.
+// Renders data with XML when the client's Accept header prefers application/xml or text/xml,
+// otherwise with JSON (including */*, application/* or no Accept header at all).
+func Render(w http.ResponseWriter, req *http.Request, data interface{}, status int) {
+	w.Header().Add("Vary", "Accept")
+
+	contentType := "application/json"
+	marshal := json.Marshal
+	if prefersXML(req.Header.Get("Accept")) {
+		contentType = "application/xml"
+		marshal = xml.Marshal
+	}
+
+	body, err := marshal(data)
 	if err != nil {
-		log.Print("Internal Server Error - HTML Template Response")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		internalError(w, req, err)
 		return
 	}
 
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		log.Printf("Render Response - write failed: %v", err)
+	}
+}
+
+// Reports whether XML is the most preferred of the media types that Render supports
+func prefersXML(accept string) bool {
+	bestQ, xml := 0.0, false
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, err := strconv.ParseFloat(params["q"], 64); err == nil {
+			q = v
+		}
+
+		var isXML bool
+		switch mediaType {
+		case "application/xml", "text/xml":
+			isXML = true
+		case "application/json", "application/*", "*/*":
+			isXML = false
+		default:
+			continue
+		}
+
+		// Ties go to the first media range listed
+		if q > bestQ {
+			bestQ, xml = q, isXML
+		}
+	}
+
+	return xml
+}
+
+/* -------------------------- ERROR-RETURNING RENDERERS ------------------------ */
+
+//
+// Variants of the renderers above that return the marshal/write error to the caller
+// instead of logging it and writing a 500.
+// Nothing is written to the ResponseWriter when marshalling fails, letting the handler decide.
+//
+
+// JSON renderer that returns an error.
+// Sets the Content-Type header to application/json and the status code
+func JSONErr(w http.ResponseWriter, data interface{}, status int) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(jsonData)
+	return err
+}
+
+// Plain Text renderer that returns an error.
+// Sets the Content-Type header to text/plain and the status code
+func TextErr(w http.ResponseWriter, data string, status int) error {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(status)
+	_, err := io.WriteString(w, data)
+	return err
+}
+
+// XML renderer that returns an error.
+// Sets the Content-Type header to application/xml and the status code
+func XMLErr(w http.ResponseWriter, data interface{}, status int) error {
+	xmlData, err := xml.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, err = w.Write(xmlData)
+	return err
+}
+
+// HTML template renderer that returns an error.
+// Sets the Content-Type header to text/html.
+// Templates are parsed and executed before anything is written.
+func HTMLErr(w http.ResponseWriter, data interface{}, htmlFiles ...string) error {
+	t, err := template.ParseFiles(htmlFiles...)
+	if err != nil {
+		return err
+	}
+
+	htmlBuffer := new(bytes.Buffer)
+	if err := t.Execute(htmlBuffer, data); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	_, err = htmlBuffer.WriteTo(w)
+	return err
+}
+
+/* -------------------------- PLUGGABLE RENDERERS ------------------------ */
+
+//
+// Custom serializers (msgpack, protobuf etc.) implement Renderer and are used with RenderWith,
+// the built-in JSON, XML and Text renderers are available as JSONRenderer, XMLRenderer and TextRenderer.
+//
+
+// Renderer writes data as the response with the given status code.
+// It should return marshalling errors before writing anything, so that RenderWith can respond with a 500.
+type Renderer interface {
+	Render(w http.ResponseWriter, data interface{}, status int) error
+}
+
+// RendererFunc adapts an ordinary function to a Renderer
+type RendererFunc func(w http.ResponseWriter, data interface{}, status int) error
+
+// Render calls f(w, data, status)
+func (f RendererFunc) Render(w http.ResponseWriter, data interface{}, status int) error {
+	return f(w, data, status)
+}
+
+// Built-in renderers
+var (
+	// JSONRenderer renders data as application/json, see JSONErr
+	JSONRenderer Renderer = RendererFunc(JSONErr)
+
+	// XMLRenderer renders data as application/xml, see XMLErr
+	XMLRenderer Renderer = RendererFunc(XMLErr)
+
+	// TextRenderer renders data formatted with fmt.Sprint as text/plain, see TextErr
+	TextRenderer Renderer = RendererFunc(func(w http.ResponseWriter, data interface{}, status int) error {
+		return TextErr(w, fmt.Sprint(data), status)
+	})
+)
+
+// RenderWith renders data with the given Renderer, eg. a msgpack serializer -
+//
+//	jett.RenderWith(w, msgpackRenderer, data, 200)
+//
+// Works like the built-in renderers, a rendering error is logged and answered with a 500
+// unless the renderer already started the response (eg. the error came from writing the body).
+func RenderWith(w http.ResponseWriter, renderer Renderer, data interface{}, status int) {
+	rw := &renderWriter{ResponseWriter: w}
+	if err := renderer.Render(rw, data, status); err != nil {
+		log.Printf("Internal Server Error - Render: %v", err)
+		if !rw.wrote {
+			internalError(w, nil, err)
+		}
+	}
+}
+
+// Records whether a Renderer started the response
+type renderWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (rw *renderWriter) WriteHeader(code int) {
+	rw.wrote = true
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *renderWriter) Write(buf []byte) (int, error) {
+	rw.wrote = true
+	return rw.ResponseWriter.Write(buf)
+}
+
+// Returns the wrapped http.ResponseWriter, for http.ResponseController
+func (rw *renderWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
 }
@@ -1,11 +1,23 @@
 package jett
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/saurabh0719/jett/jetttest"
 )
 
 func TestURLParams(t *testing.T) {
@@ -39,6 +51,32 @@ func TestURLParams(t *testing.T) {
 
 }
 
+func TestQueryParamsBrackets(t *testing.T) {
+	tests := []string{
+		"/?filter[]=a&filter[]=b",
+		"/?filter[1]=b&filter[0]=a",
+		"/?filter=a&filter=b",
+	}
+
+	expected := []string{"a", "b"}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", test, nil)
+
+		params := QueryParams(req)
+		if !reflect.DeepEqual(params["filter"], expected) {
+			t.Fatalf("QueryParams -> %s Expected : %v, Output : %v", test, expected, params)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/?user[name]=jett&one[=1", nil)
+	params := QueryParams(req)
+
+	if params["user[name]"][0] != "jett" || params["one["][0] != "1" {
+		t.Fatalf("QueryParams -> Expected non-indexed brackets to be kept as is, Output : %v", params)
+	}
+}
+
 func TestGetFullPath(t *testing.T) {
 	r := New()
 
@@ -73,6 +111,1077 @@ func TestSubrouter(t *testing.T) {
 
 }
 
+func TestHandleMux(t *testing.T) {
+	r := New()
+
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("X-Jett", "true")
+			next.ServeHTTP(w, req)
+		})
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", func(w http.ResponseWriter, req *http.Request) {
+		Text(w, "users", http.StatusOK)
+	})
+	mux.HandleFunc("/orders/", func(w http.ResponseWriter, req *http.Request) {
+		Text(w, "orders "+req.URL.Path, http.StatusOK)
+	})
+
+	r.Subrouter("/api").HandleMux("/legacy", mux)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+		body   string
+	}{
+		{"GET", "/api/legacy/users", http.StatusOK, "users"},
+		{"POST", "/api/legacy/orders/42", http.StatusOK, "orders /orders/42"},
+		{"GET", "/api/legacy/missing", http.StatusNotFound, "404 page not found\n"},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest(test.method, ts.URL+test.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != test.status || string(body) != test.body {
+			t.Fatalf("HandleMux %s %s -> Expected : %d %q, Output : %d %q", test.method, test.path, test.status, test.body, res.StatusCode, body)
+		}
+
+		if res.Header.Get("X-Jett") != "true" {
+			t.Fatalf("HandleMux %s %s -> Expected the router's middleware to run", test.method, test.path)
+		}
+	}
+}
+
+func TestJSONIndent(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	JSONIndent(w, map[string]int{"a": 1}, 201, "  ")
+
+	expected := "{\n  \"a\": 1\n}"
+	if w.Code != 201 || w.Body.String() != expected || w.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("JSONIndent -> Expected : 201 %s, Output : %d %s", expected, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+
+	JSONIndent(w, make(chan int), 200, "  ")
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("JSONIndent -> Expected : %d, Output : %d", http.StatusInternalServerError, w.Code)
+	}
+}
+
+func TestJSONP(t *testing.T) {
+	tests := []struct {
+		callback    string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"jQuery_123.cb$", 200, "application/javascript", `jQuery_123.cb$({"a":1});`},
+		{"", 200, "application/json", `{"a":1}`},
+		{"alert(1);cb", http.StatusBadRequest, "text/plain; charset=utf-8", "Invalid JSONP callback\n"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+
+		JSONP(w, map[string]int{"a": 1}, test.callback, 200)
+
+		if w.Code != test.status || w.Header().Get("Content-Type") != test.contentType || w.Body.String() != test.body {
+			t.Fatalf("JSONP %q -> Expected : %d %s %s, Output : %d %s %s", test.callback, test.status, test.contentType, test.body, w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+	}
+}
+
+func TestJSONStream(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	JSONStream(w, []int{1, 2, 3}, 201)
+
+	if w.Code != 201 || w.Header().Get("Content-Type") != "application/json" || w.Body.String() != "[1,2,3]\n" {
+		t.Fatalf("JSONStream -> Expected : 201 [1,2,3], Output : %d %s", w.Code, w.Body.String())
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	// Headers are already sent, the status can't change
+	w = httptest.NewRecorder()
+
+	JSONStream(w, make(chan int), 200)
+
+	if w.Code != 200 {
+		t.Fatalf("JSONStream encoding error -> Expected : %d, Output : %d", 200, w.Code)
+	}
+}
+
+func TestSSE(t *testing.T) {
+	rec := jetttest.NewFlushRecorder()
+	req := httptest.NewRequest("GET", "/events", nil)
+
+	events := make(chan string, 2)
+	events <- "one"
+	events <- "two\nlines"
+	close(events)
+
+	SSE(rec, req, events)
+
+	expected := []string{"data: one\n\n", "data: two\ndata: lines\n\n"}
+	if !reflect.DeepEqual(rec.Chunks(), expected) {
+		t.Fatalf("SSE -> Expected : %q, Output : %q", expected, rec.Chunks())
+	}
+
+	if output := rec.Header().Get("Content-Type"); output != "text/event-stream" {
+		t.Fatalf("SSE Content-Type -> Expected : text/event-stream, Output : %s", output)
+	}
+
+	// Returns once the client goes away, even if the channel stays open
+	ctx, cancel := context.WithCancel(context.Background())
+	req = httptest.NewRequest("GET", "/events", nil).WithContext(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		SSE(jetttest.NewFlushRecorder(), req, make(chan string))
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("SSE -> Expected to return when the request context is cancelled")
+	}
+}
+
+func TestRender(t *testing.T) {
+	type user struct {
+		Name string
+	}
+
+	tests := []struct {
+		accept string
+		body   string
+	}{
+		{"application/json", `{"Name":"jett"}`},
+		{"application/xml", "<user><Name>jett</Name></user>"},
+		{"text/html, text/xml;q=0.9, */*;q=0.8", "<user><Name>jett</Name></user>"},
+		{"application/xml;q=0.5, application/json", `{"Name":"jett"}`},
+		{"*/*", `{"Name":"jett"}`},
+		{"", `{"Name":"jett"}`},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept", test.accept)
+
+		w := httptest.NewRecorder()
+		Render(w, req, user{Name: "jett"}, 200)
+
+		if w.Code != 200 || w.Body.String() != test.body {
+			t.Fatalf("Render %q -> Expected : 200 %s, Output : %d %s", test.accept, test.body, w.Code, w.Body.String())
+		}
+
+		if w.Header().Get("Vary") != "Accept" {
+			t.Fatalf("Render %q Vary -> Expected : Accept, Output : %s", test.accept, w.Header().Get("Vary"))
+		}
+	}
+}
+
+func TestBlob(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	png := []byte("\x89PNG\r\n\x1a\n")
+	Blob(w, "image/png", png, http.StatusCreated)
+
+	if w.Code != http.StatusCreated || w.Header().Get("Content-Type") != "image/png" || !reflect.DeepEqual(w.Body.Bytes(), png) {
+		t.Fatalf("Blob -> Expected : 201 image/png %q, Output : %d %s %q", png, w.Code, w.Header().Get("Content-Type"), w.Body.Bytes())
+	}
+}
+
+func TestStatus(t *testing.T) {
+	r := New()
+	r.DELETE("/users/:id", func(w http.ResponseWriter, req *http.Request) {
+		Status(w, http.StatusNoContent)
+	}).Produces("application/json")
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, err := http.NewRequest("DELETE", ts.URL+"/users/42", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent || len(body) != 0 || res.Header.Get("Content-Type") != "" {
+		t.Fatalf("Status -> Expected : 204 without a body or Content-Type, Output : %d %q %q", res.StatusCode, body, res.Header.Get("Content-Type"))
+	}
+}
+
+func TestRedirect(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	tests := []struct {
+		status   int
+		expected int
+	}{
+		{http.StatusMovedPermanently, http.StatusMovedPermanently},
+		{http.StatusTemporaryRedirect, http.StatusTemporaryRedirect},
+		{http.StatusOK, http.StatusFound},
+		{http.StatusNotFound, http.StatusFound},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/old", nil)
+		w := httptest.NewRecorder()
+
+		Redirect(w, req, "/new", test.status)
+
+		if w.Code != test.expected || w.Header().Get("Location") != "/new" {
+			t.Fatalf("Redirect %d -> Expected : %d /new, Output : %d %s", test.status, test.expected, w.Code, w.Header().Get("Location"))
+		}
+	}
+}
+
+func TestDownload(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "report.csv")
+	if err := os.WriteFile(filePath, []byte("a,b,c"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := New()
+	r.NotFound(func(w http.ResponseWriter, req *http.Request) {
+		Text(w, "no such file", http.StatusNotFound)
+	})
+	r.GET("/report", func(w http.ResponseWriter, req *http.Request) {
+		Download(w, req, filePath, "")
+	})
+	r.GET("/renamed", func(w http.ResponseWriter, req *http.Request) {
+		Download(w, req, filePath, "q1 report.csv")
+	})
+	r.GET("/missing", func(w http.ResponseWriter, req *http.Request) {
+		Download(w, req, filepath.Join(dir, "missing.csv"), "")
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path        string
+		rangeHeader string
+		status      int
+		disposition string
+		body        string
+	}{
+		{"/report", "", http.StatusOK, "attachment; filename=report.csv", "a,b,c"},
+		{"/renamed", "", http.StatusOK, `attachment; filename="q1 report.csv"`, "a,b,c"},
+		{"/report", "bytes=2-3", http.StatusPartialContent, "attachment; filename=report.csv", "b,"},
+		{"/missing", "", http.StatusNotFound, "", "no such file"},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", ts.URL+test.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.rangeHeader != "" {
+			req.Header.Set("Range", test.rangeHeader)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != test.status || string(body) != test.body {
+			t.Fatalf("Download %s -> Expected : %d %q, Output : %d %q", test.path, test.status, test.body, res.StatusCode, body)
+		}
+
+		if output := res.Header.Get("Content-Disposition"); output != test.disposition {
+			t.Fatalf("Download %s Content-Disposition -> Expected : %s, Output : %s", test.path, test.disposition, output)
+		}
+
+		if test.status != http.StatusNotFound && !strings.HasPrefix(res.Header.Get("Content-Type"), "text/csv") {
+			t.Fatalf("Download %s Content-Type -> Expected : text/csv, Output : %s", test.path, res.Header.Get("Content-Type"))
+		}
+	}
+}
+
+func TestJSONErr(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	err := JSONErr(w, make(chan int), 200)
+	if err == nil {
+		t.Fatalf("JSONErr -> Expected an error for an unmarshalable value")
+	}
+
+	if w.Body.Len() != 0 || w.Header().Get("Content-Type") != "" {
+		t.Fatalf("JSONErr -> Expected nothing to be written, Output : %q", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+
+	if err := JSONErr(w, "hello", 201); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Code != 201 || w.Body.String() != `"hello"` || w.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("JSONErr -> Expected : 201 \"hello\", Output : %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestXMLErr(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	err := XMLErr(w, make(chan int), 200)
+	if err == nil {
+		t.Fatalf("XMLErr -> Expected an error for an unmarshalable value")
+	}
+
+	if w.Body.Len() != 0 {
+		t.Fatalf("XMLErr -> Expected nothing to be written, Output : %q", w.Body.String())
+	}
+}
+
+func TestHTMLErr(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	err := HTMLErr(w, nil, "does-not-exist.html")
+	if err == nil {
+		t.Fatalf("HTMLErr -> Expected an error for a missing template")
+	}
+
+	if w.Body.Len() != 0 {
+		t.Fatalf("HTMLErr -> Expected nothing to be written, Output : %q", w.Body.String())
+	}
+}
+
+func TestWaitForReady(t *testing.T) {
+	r := New()
+
+	calls := 0
+	r.WaitForReady(func() error {
+		calls++
+		return nil
+	})
+
+	if err := r.checkReady(); err != nil || calls != 1 {
+		t.Fatalf("WaitForReady -> Expected : <nil> after 1 check, Output : %v after %d checks", err, calls)
+	}
+
+	errNotReady := errors.New("db not connected")
+	r.WaitForReady(func() error {
+		return errNotReady
+	}, func() error {
+		t.Fatalf("WaitForReady -> Expected checks after a failure to be skipped")
+		return nil
+	})
+
+	if err := r.checkReady(); err != errNotReady {
+		t.Fatalf("WaitForReady -> Expected : %v, Output : %v", errNotReady, err)
+	}
+}
+
+func TestPre(t *testing.T) {
+	r := New()
+
+	r.Pre(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("User-Agent") == "bad-bot" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	})
+
+	r.GET("/", Home)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path      string
+		userAgent string
+		status    int
+	}{
+		{"/does-not-exist", "bad-bot", http.StatusForbidden},
+		{"/", "bad-bot", http.StatusForbidden},
+		{"/does-not-exist", "jett", http.StatusNotFound},
+		{"/", "jett", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", ts.URL+test.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("User-Agent", test.userAgent)
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("Pre -> %s %s Expected : %d, Output : %d", test.userAgent, test.path, test.status, res.StatusCode)
+		}
+	}
+}
+
+func TestPreComposedOnce(t *testing.T) {
+	r := New()
+
+	composed := 0
+	counting := func(next http.Handler) http.Handler {
+		composed++
+		return next
+	}
+
+	r.Pre(counting)
+	r.GET("/", Home)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	get := func() {
+		res, err := http.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	get()
+	get()
+	if composed != 1 {
+		t.Fatalf("Pre composed -> Expected : %d, Output : %d", 1, composed)
+	}
+
+	// Adding Pre middleware composes the chain again
+	r.Pre(counting)
+	get()
+	if composed != 3 {
+		t.Fatalf("Pre composed after Pre -> Expected : %d, Output : %d", 3, composed)
+	}
+}
+
+func TestMountCSPReports(t *testing.T) {
+	r := New()
+
+	reports := make(chan []byte, 1)
+	r.MountCSPReports("/csp-reports", func(report []byte) {
+		reports <- report
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	report := `{"csp-report":{"document-uri":"https://example.com/","violated-directive":"script-src"}}`
+
+	res, err := http.Post(ts.URL+"/csp-reports", "application/csp-report", strings.NewReader(report))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("MountCSPReports -> Expected : %d, Output : %d", http.StatusNoContent, res.StatusCode)
+	}
+
+	select {
+	case received := <-reports:
+		if string(received) != report {
+			t.Fatalf("MountCSPReports -> Expected : %s, Output : %s", report, received)
+		}
+	default:
+		t.Fatalf("MountCSPReports -> Expected the handler to receive the report")
+	}
+
+	res, err = http.Post(ts.URL+"/csp-reports", "text/plain", strings.NewReader(report))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusUnsupportedMediaType {
+		t.Fatalf("MountCSPReports -> Expected : %d, Output : %d", http.StatusUnsupportedMediaType, res.StatusCode)
+	}
+}
+
+func TestCSV(t *testing.T) {
+	rows := [][]string{
+		{"jett", "says \"hello\""},
+		{"go", "a,b"},
+	}
+
+	w := httptest.NewRecorder()
+	CSV(w, 200, rows, []string{"name", "message"})
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := append([][]string{{"name", "message"}}, rows...)
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("CSV -> Expected : %v, Output : %v", expected, records)
+	}
+
+	if output := w.Header().Get("Content-Disposition"); output != "attachment" {
+		t.Fatalf("CSV Content-Disposition -> Expected : attachment, Output : %s", output)
+	}
+
+	if output := w.Header().Get("Content-Type"); output != "text/csv" {
+		t.Fatalf("CSV Content-Type -> Expected : text/csv, Output : %s", output)
+	}
+}
+
+func TestCSVFile(t *testing.T) {
+	rows := [][]string{
+		{"name", "message"},
+		{"jett", "says \"hello\""},
+	}
+
+	w := httptest.NewRecorder()
+	if err := CSVFile(w, rows, "report.csv", 200); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(records, rows) {
+		t.Fatalf("CSVFile -> Expected : %v, Output : %v", rows, records)
+	}
+
+	if output := w.Header().Get("Content-Disposition"); output != "attachment; filename=report.csv" {
+		t.Fatalf("CSVFile Content-Disposition -> Expected : attachment; filename=report.csv, Output : %s", output)
+	}
+
+	w = httptest.NewRecorder()
+	CSVFile(w, rows, "", 200)
+
+	if output := w.Header().Get("Content-Disposition"); output != "" {
+		t.Fatalf("CSVFile Content-Disposition without filename -> Expected : \"\", Output : %s", output)
+	}
+
+	// Write errors are surfaced
+	fw := &failingWriter{header: http.Header{}}
+	if err := CSVFile(fw, rows, "report.csv", 200); err == nil {
+		t.Fatalf("CSVFile -> Expected the write error to be returned")
+	}
+}
+
+func TestCSVStream(t *testing.T) {
+	rows := [][]string{{"1", "one"}, {"2", "two, three"}}
+
+	rowCh := make(chan []string)
+	go func() {
+		for _, row := range rows {
+			rowCh <- row
+		}
+		close(rowCh)
+	}()
+
+	w := httptest.NewRecorder()
+	CSVStream(w, 200, rowCh)
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(records, rows) {
+		t.Fatalf("CSVStream -> Expected : %v, Output : %v", rows, records)
+	}
+
+	if output := w.Header().Get("Content-Disposition"); output != "attachment" {
+		t.Fatalf("CSVStream Content-Disposition -> Expected : attachment, Output : %s", output)
+	}
+}
+
+// ResponseWriter whose body writes always fail, eg. the client went away
+type failingWriter struct {
+	header      http.Header
+	writeHeader int
+	writes      int
+}
+
+func (f *failingWriter) Header() http.Header {
+	return f.header
+}
+
+func (f *failingWriter) WriteHeader(status int) {
+	f.writeHeader++
+}
+
+func (f *failingWriter) Write(b []byte) (int, error) {
+	f.writes++
+	return 0, errors.New("broken pipe")
+}
+
+func TestTextWriteError(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	w := &failingWriter{header: http.Header{}}
+
+	Text(w, "Hello", http.StatusOK)
+
+	if w.writeHeader != 1 {
+		t.Fatalf("Text write error - WriteHeader calls -> Expected : %d, Output : %d", 1, w.writeHeader)
+	}
+
+	if w.writes != 1 {
+		t.Fatalf("Text write error - Write calls -> Expected : %d, Output : %d", 1, w.writes)
+	}
+}
+
+func TestTextFormatVerbs(t *testing.T) {
+	data := "100% done %s %!d(MISSING)"
+
+	w := httptest.NewRecorder()
+	Text(w, data, http.StatusOK)
+
+	if w.Body.String() != data {
+		t.Fatalf("Text format verbs -> Expected : %s, Output : %s", data, w.Body.String())
+	}
+}
+
+func TestHTMLFormatVerbs(t *testing.T) {
+	page := filepath.Join(t.TempDir(), "page.html")
+	os.WriteFile(page, []byte(`<p>100% {{.}}</p>`), 0644)
+
+	w := httptest.NewRecorder()
+	HTML(w, "%s done", page)
+
+	expected := "<p>100% %s done</p>"
+	if w.Body.String() != expected {
+		t.Fatalf("HTML format verbs -> Expected : %s, Output : %s", expected, w.Body.String())
+	}
+}
+
+func TestJSONBytes(t *testing.T) {
+	raw := []byte(`{"name":"jett","tags":["go"]}`)
+
+	w := httptest.NewRecorder()
+	JSONBytes(w, raw, http.StatusCreated)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("JSONBytes status -> Expected : %d, Output : %d", http.StatusCreated, w.Code)
+	}
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Fatalf("JSONBytes Content-Type -> Expected : %s, Output : %s", "application/json", contentType)
+	}
+
+	if w.Body.String() != string(raw) {
+		t.Fatalf("JSONBytes body -> Expected : %s, Output : %s", raw, w.Body.Bytes())
+	}
+}
+
+func TestExposeHeader(t *testing.T) {
+	r := New()
+
+	r.GET("/users", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Access-Control-Expose-Headers", "Link")
+		w.Header().Set("X-Total-Count", "42")
+		ExposeHeader(w, "X-Total-Count")
+		ExposeHeader(w, "x-total-count")
+		ExposeHeader(w, "link")
+		JSON(w, []string{}, http.StatusOK)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	exposed := res.Header.Values("Access-Control-Expose-Headers")
+	expected := []string{"Link", "X-Total-Count"}
+
+	if !reflect.DeepEqual(exposed, expected) {
+		t.Fatalf("ExposeHeader -> Expected : %v, Output : %v", expected, exposed)
+	}
+}
+
+func TestJSONUnescaped(t *testing.T) {
+	data := map[string]string{"url": "https://jett.dev/search?q=<go>&page=2"}
+
+	w := httptest.NewRecorder()
+	JSONUnescaped(w, data, http.StatusOK)
+
+	expected := `{"url":"https://jett.dev/search?q=<go>&page=2"}`
+	if w.Body.String() != expected {
+		t.Fatalf("JSONUnescaped -> Expected : %s, Output : %s", expected, w.Body.String())
+	}
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Fatalf("JSONUnescaped Content-Type -> Expected : %s, Output : %s", "application/json", contentType)
+	}
+
+	w = httptest.NewRecorder()
+	JSONUnescaped(w, make(chan int), http.StatusOK)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("JSONUnescaped error -> Expected : %d, Output : %d", http.StatusInternalServerError, w.Code)
+	}
+}
+
+func TestRendererContentType(t *testing.T) {
+	r := New()
+
+	r.GET("/json", func(w http.ResponseWriter, req *http.Request) {
+		JSON(w, map[string]string{"name": "jett"}, http.StatusCreated)
+	})
+	r.GET("/xml", func(w http.ResponseWriter, req *http.Request) {
+		XML(w, "jett", http.StatusCreated)
+	})
+	r.GET("/text", func(w http.ResponseWriter, req *http.Request) {
+		Text(w, "jett", http.StatusCreated)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path        string
+		contentType string
+	}{
+		{"/json", "application/json"},
+		{"/xml", "application/xml"},
+		{"/text", "text/plain"},
+	}
+
+	for _, test := range tests {
+		res, err := http.Get(ts.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusCreated {
+			t.Fatalf("%s status -> Expected : %d, Output : %d", test.path, http.StatusCreated, res.StatusCode)
+		}
+
+		if contentType := res.Header.Get("Content-Type"); contentType != test.contentType {
+			t.Fatalf("%s Content-Type -> Expected : %s, Output : %s", test.path, test.contentType, contentType)
+		}
+	}
+}
+
+func TestRenderWith(t *testing.T) {
+	upper := RendererFunc(func(w http.ResponseWriter, data interface{}, status int) error {
+		text, ok := data.(string)
+		if !ok {
+			return errors.New("not a string")
+		}
+		return TextErr(w, strings.ToUpper(text), status)
+	})
+
+	tests := []struct {
+		name        string
+		renderer    Renderer
+		data        interface{}
+		status      int
+		contentType string
+		body        string
+	}{
+		{"JSONRenderer", JSONRenderer, map[string]int{"id": 1}, http.StatusCreated, "application/json", `{"id":1}`},
+		{"XMLRenderer", XMLRenderer, "jett", http.StatusOK, "application/xml", "<string>jett</string>"},
+		{"TextRenderer", TextRenderer, 42, http.StatusOK, "text/plain", "42"},
+		{"RendererFunc", upper, "jett", http.StatusAccepted, "text/plain", "JETT"},
+		{"RendererFunc error", upper, 42, http.StatusInternalServerError, "text/plain; charset=utf-8", "Internal Server Error\n"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		RenderWith(w, test.renderer, test.data, test.status)
+
+		if w.Code != test.status || w.Header().Get("Content-Type") != test.contentType || w.Body.String() != test.body {
+			t.Fatalf("RenderWith %s -> Expected : %d %s %q, Output : %d %s %q", test.name,
+				test.status, test.contentType, test.body, w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+	}
+}
+
+func TestRenderWithWriteError(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	w := &failingWriter{header: http.Header{}}
+
+	RenderWith(w, JSONRenderer, map[string]int{"id": 1}, http.StatusCreated)
+
+	// The response was already started, no 500 is written over it
+	if w.writeHeader != 1 || w.writes != 1 {
+		t.Fatalf("RenderWith write error -> Expected : 1 WriteHeader & 1 Write, Output : %d & %d", w.writeHeader, w.writes)
+	}
+
+	if !strings.Contains(output.String(), "broken pipe") {
+		t.Fatalf("RenderWith write error -> Expected the error to be logged, Output : %q", output.String())
+	}
+}
+
+// Records the targets pushed
+type pushRecorder struct {
+	*httptest.ResponseRecorder
+	pushed []string
+}
+
+func (pr *pushRecorder) Push(target string, opts *http.PushOptions) error {
+	pr.pushed = append(pr.pushed, target)
+	return nil
+}
+
+func TestPush(t *testing.T) {
+	w := &pushRecorder{ResponseRecorder: httptest.NewRecorder()}
+
+	if err := Push(w, "/static/styles.css", nil); err != nil {
+		t.Fatalf("Push -> Expected : <nil>, Output : %v", err)
+	}
+
+	if !reflect.DeepEqual(w.pushed, []string{"/static/styles.css"}) {
+		t.Fatalf("Push -> Expected : %v, Output : %v", []string{"/static/styles.css"}, w.pushed)
+	}
+
+	if err := Push(httptest.NewRecorder(), "/static/styles.css", nil); err != http.ErrNotSupported {
+		t.Fatalf("Push unsupported -> Expected : %v, Output : %v", http.ErrNotSupported, err)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	r := New()
+
+	r.GET("/users", Home)
+	r.PUT("/users", Home)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/users", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusNotFound {
+		t.Fatalf("MethodNotAllowed default -> Expected : %d, Output : %d", http.StatusNotFound, res.StatusCode)
+	}
+
+	r.MethodNotAllowed(func(w http.ResponseWriter, req *http.Request) {
+		JSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+	})
+
+	res, err = http.Post(ts.URL+"/users", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("MethodNotAllowed -> Expected : %d, Output : %d", http.StatusMethodNotAllowed, res.StatusCode)
+	}
+
+	if allow := res.Header.Get("Allow"); allow != "GET, OPTIONS, PUT" {
+		t.Fatalf("MethodNotAllowed Allow -> Expected : %s, Output : %s", "GET, OPTIONS, PUT", allow)
+	}
+
+	expected := `{"error":{"code":405,"message":"method not allowed"}}`
+	if string(body) != expected {
+		t.Fatalf("MethodNotAllowed body -> Expected : %s, Output : %s", expected, body)
+	}
+}
+
+func TestGlobalOPTIONS(t *testing.T) {
+	r := New()
+
+	r.GET("/users", Home)
+	r.POST("/users", Home)
+
+	r.GlobalOPTIONS(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Access-Control-Allow-Methods", w.Header().Get("Allow"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	options := func() *http.Response {
+		req, err := http.NewRequest(http.MethodOptions, ts.URL+"/users", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Access-Control-Request-Method", "POST")
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res
+	}
+
+	res := options()
+
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("GlobalOPTIONS -> Expected : %d, Output : %d", http.StatusNoContent, res.StatusCode)
+	}
+
+	if methods := res.Header.Get("Access-Control-Allow-Methods"); methods != "GET, OPTIONS, POST" {
+		t.Fatalf("GlobalOPTIONS Access-Control-Allow-Methods -> Expected : %s, Output : %s", "GET, OPTIONS, POST", methods)
+	}
+
+	r.HandleOPTIONS(false)
+
+	if res := options(); res.StatusCode != http.StatusNotFound {
+		t.Fatalf("HandleOPTIONS disabled -> Expected : %d, Output : %d", http.StatusNotFound, res.StatusCode)
+	}
+}
+
+func TestRedirectTrailingSlashFixedPath(t *testing.T) {
+	r := New()
+
+	r.GET("/users", Home)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	get := func(path string) *http.Response {
+		res, err := client.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res
+	}
+
+	tests := []struct {
+		path     string
+		status   int
+		location string
+	}{
+		{"/users/", http.StatusMovedPermanently, "/users"},
+		{"/USERS", http.StatusMovedPermanently, "/users"},
+	}
+
+	for _, test := range tests {
+		res := get(test.path)
+		if res.StatusCode != test.status || res.Header.Get("Location") != test.location {
+			t.Fatalf("Redirect %s -> Expected : %d %s, Output : %d %s", test.path, test.status, test.location, res.StatusCode, res.Header.Get("Location"))
+		}
+	}
+
+	r.RedirectTrailingSlash(false)
+	r.RedirectFixedPath(false)
+
+	for _, test := range tests {
+		if res := get(test.path); res.StatusCode != http.StatusNotFound {
+			t.Fatalf("Redirect disabled %s -> Expected : %d, Output : %d", test.path, http.StatusNotFound, res.StatusCode)
+		}
+	}
+}
+
+func TestMount(t *testing.T) {
+	r := New()
+
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("X-Jett", "true")
+			next.ServeHTTP(w, req)
+		})
+	})
+
+	admin := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		Text(w, req.Method+" "+req.URL.Path, http.StatusOK)
+	})
+
+	r.Mount("/admin/", admin)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"GET", "/admin/stats", "GET /stats"},
+		{"DELETE", "/admin/cache/users", "DELETE /cache/users"},
+		{"GET", "/admin/", "GET /"},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest(test.method, ts.URL+test.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK || string(body) != test.body {
+			t.Fatalf("Mount %s %s -> Expected : 200 %q, Output : %d %q", test.method, test.path, test.body, res.StatusCode, body)
+		}
+
+		if res.Header.Get("X-Jett") != "true" {
+			t.Fatalf("Mount %s %s -> Expected the router's middleware to run", test.method, test.path)
+		}
+	}
+}
+
 func Home(w http.ResponseWriter, req *http.Request) {
 	params := URLParams(req)
 	JSON(w, params, 200)
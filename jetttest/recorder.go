@@ -0,0 +1,71 @@
+// The jetttest package contains utilities for testing handlers
+// built with Jett! https://github.com/saurabh0719/jett
+package jetttest
+
+import (
+	"net/http/httptest"
+	"sync"
+)
+
+// FlushRecorder is an httptest.ResponseRecorder that implements http.Flusher
+// and records the output written between flushes, so that tests can assert
+// the incremental output of streaming handlers (SSE, long-polling etc.)
+//
+//	rec := jetttest.NewFlushRecorder()
+//	handler.ServeHTTP(rec, req)
+//	rec.Chunks() // ["data: one\n\n", "data: two\n\n"]
+//
+// Writes and flushes are safe to call from another goroutine than the one
+// reading Chunks.
+type FlushRecorder struct {
+	*httptest.ResponseRecorder
+
+	mu      sync.Mutex
+	chunks  []string
+	pending []byte
+}
+
+// Create a new FlushRecorder
+func NewFlushRecorder() *FlushRecorder {
+	return &FlushRecorder{
+		ResponseRecorder: httptest.NewRecorder(),
+	}
+}
+
+// Implement http.ResponseWriter interface
+func (rec *FlushRecorder) Write(buf []byte) (int, error) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.pending = append(rec.pending, buf...)
+	return rec.ResponseRecorder.Write(buf)
+}
+
+// Implement io.StringWriter interface
+func (rec *FlushRecorder) WriteString(str string) (int, error) {
+	return rec.Write([]byte(str))
+}
+
+// Implement http.Flusher interface.
+// Output written since the previous flush is recorded as a chunk.
+func (rec *FlushRecorder) Flush() {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if len(rec.pending) > 0 {
+		rec.chunks = append(rec.chunks, string(rec.pending))
+		rec.pending = nil
+	}
+	rec.ResponseRecorder.Flush()
+}
+
+// Chunks returns the output flushed so far, one chunk per flush.
+// Output written after the last flush isn't included.
+func (rec *FlushRecorder) Chunks() []string {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	chunks := make([]string, len(rec.chunks))
+	copy(chunks, rec.chunks)
+	return chunks
+}
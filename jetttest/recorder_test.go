@@ -0,0 +1,41 @@
+package jetttest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// Streams each event and flushes after every one of them
+func stream(events ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			io.WriteString(w, "data: "+event+"\n\n")
+			w.(http.Flusher).Flush()
+		}
+		io.WriteString(w, "unflushed")
+	}
+}
+
+func TestFlushRecorder(t *testing.T) {
+	rec := NewFlushRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+
+	stream("one", "two")(rec, req)
+
+	expected := []string{"data: one\n\n", "data: two\n\n"}
+	if !reflect.DeepEqual(rec.Chunks(), expected) {
+		t.Fatalf("FlushRecorder.Chunks -> Expected : %q, Output : %q", expected, rec.Chunks())
+	}
+
+	if body := rec.Body.String(); body != "data: one\n\ndata: two\n\nunflushed" {
+		t.Fatalf("FlushRecorder.Body -> Output : %q", body)
+	}
+
+	if !rec.Flushed {
+		t.Fatalf("FlushRecorder.Flushed -> Expected : true")
+	}
+}
@@ -0,0 +1,40 @@
+package jett
+
+import (
+	"net/http"
+	"time"
+)
+
+/* -------------------------- LONG POLLING ------------------------- */
+
+// LongPoll blocks a long-poll handler until data is received on ready, for up to wait.
+// Returns the data and true once it arrives, the handler then renders it as usual -
+//
+//	data, ok := jett.LongPoll(w, req, 30*time.Second, updates)
+//	if !ok {
+//		return
+//	}
+//	jett.JSON(w, data, http.StatusOK)
+//
+// Returns false when there's nothing to render - if wait elapses (or ready is closed) it has
+// responded with 204 No Content for the client to try again, if the client went away nothing is written.
+// The connection is marked long-lived (see MarkLongLived).
+func LongPoll(w http.ResponseWriter, req *http.Request, wait time.Duration, ready <-chan interface{}) (interface{}, bool) {
+	MarkLongLived(req)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case data, ok := <-ready:
+		if ok {
+			return data, true
+		}
+	case <-timer.C:
+	case <-req.Context().Done():
+		return nil, false
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil, false
+}
@@ -0,0 +1,45 @@
+package jett
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLongPoll(t *testing.T) {
+	// Data arrives before the timeout
+	ready := make(chan interface{}, 1)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		ready <- "update"
+	}()
+
+	w := httptest.NewRecorder()
+	data, ok := LongPoll(w, httptest.NewRequest("GET", "/poll", nil), time.Second, ready)
+
+	if !ok || data != "update" || w.Body.Len() != 0 {
+		t.Fatalf("LongPoll -> Expected : update true with nothing written, Output : %v %t %d", data, ok, w.Code)
+	}
+
+	// Times out
+	w = httptest.NewRecorder()
+	start := time.Now()
+	data, ok = LongPoll(w, httptest.NewRequest("GET", "/poll", nil), 50*time.Millisecond, make(chan interface{}))
+
+	if ok || data != nil || w.Code != http.StatusNoContent || time.Since(start) < 50*time.Millisecond {
+		t.Fatalf("LongPoll timeout -> Expected : false and 204 after 50ms, Output : %t %d after %s", ok, w.Code, time.Since(start))
+	}
+
+	// Client goes away
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	w = httptest.NewRecorder()
+	_, ok = LongPoll(w, httptest.NewRequest("GET", "/poll", nil).WithContext(ctx), time.Second, make(chan interface{}))
+
+	if ok || w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Fatalf("LongPoll disconnected -> Expected : false with nothing written, Output : %t %d", ok, w.Code)
+	}
+}
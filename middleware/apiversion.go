@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/saurabh0719/jett"
+)
+
+// APIVersion is a middleware for APIs versioned through a header.
+// The version is parsed from the media type parameter `param` or from a vendor media type -
+//
+//	Accept: application/json; version=2
+//	Accept: application/vnd.myapp.v2+json
+//
+// and stored in the request context, accessible via jett.APIVersion(req) ("2" in both cases).
+//
+// When supported versions are given, requests for any other version are rejected
+// with 406 Not Acceptable, and requests without a version fall back to the first supported one.
+//
+//	r.Use(middleware.APIVersion("Accept", "version", "2", "1"))
+func APIVersion(header, param string, supported ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			version := parseAPIVersion(req.Header.Get(header), param)
+
+			if version == "" && len(supported) > 0 {
+				version = supported[0]
+			}
+
+			if len(supported) > 0 && !contains(supported, version) {
+				http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+				return
+			}
+
+			ctx := jett.WithAPIVersion(req.Context(), version)
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// Returns the first version found in a (comma separated) list of media types
+func parseAPIVersion(value, param string) string {
+	for _, mediaRange := range strings.Split(value, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+
+		if version := params[param]; version != "" {
+			return strings.TrimPrefix(version, "v")
+		}
+
+		// Vendor media type - application/vnd.myapp.v2+json
+		subtype := mediaType[strings.IndexByte(mediaType, '/')+1:]
+		if !strings.HasPrefix(subtype, "vnd.") {
+			continue
+		}
+		if i := strings.IndexByte(subtype, '+'); i >= 0 {
+			subtype = subtype[:i]
+		}
+		if i := strings.LastIndex(subtype, ".v"); i >= 0 && i+2 < len(subtype) {
+			return subtype[i+2:]
+		}
+	}
+
+	return ""
+}
+
+func contains(list []string, str string) bool {
+	for _, item := range list {
+		if item == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareAPIVersion(t *testing.T) {
+	r := jett.New()
+
+	r.Use(APIVersion("Accept", "version", "2", "1"))
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		jett.JSON(w, jett.APIVersion(req), 200)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		accept  string
+		status  int
+		version string
+	}{
+		{"application/vnd.myapp.v1+json", http.StatusOK, "1"},
+		{"application/json; version=2", http.StatusOK, "2"},
+		{"application/vnd.myapp.v3+json", http.StatusNotAcceptable, ""},
+		{"", http.StatusOK, "2"},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.APIVersion -> %q Expected : %d, Output : %d", test.accept, test.status, res.StatusCode)
+		}
+
+		if test.status != http.StatusOK {
+			continue
+		}
+
+		var version string
+		json.Unmarshal(body, &version)
+
+		if version != test.version {
+			t.Fatalf("middleware.APIVersion -> %q Expected : %s, Output : %s", test.accept, test.version, version)
+		}
+	}
+}
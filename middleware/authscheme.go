@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Canonical casing of well known Authorization schemes, by lowercase scheme
+var authSchemes = map[string]string{
+	"basic":  "Basic",
+	"bearer": "Bearer",
+	"digest": "Digest",
+}
+
+// NormalizeAuthScheme is a middleware that rewrites the scheme of the Authorization header
+// to its canonical casing (eg. `authorization: bearer <token>` -> `Bearer <token>`),
+// so handlers and middleware down the chain can match it exactly.
+// Auth schemes are case-insensitive (RFC 7235, Section 2.1). Unknown schemes are left as is.
+func NormalizeAuthScheme(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		normalizeAuthScheme(req.Header)
+		next.ServeHTTP(w, req)
+	})
+}
+
+func normalizeAuthScheme(h http.Header) {
+	auth := h.Get("Authorization")
+
+	scheme, credentials, found := strings.Cut(auth, " ")
+	if !found {
+		return
+	}
+
+	if canonical, ok := authSchemes[strings.ToLower(scheme)]; ok && canonical != scheme {
+		h.Set("Authorization", canonical+" "+credentials)
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareBasicAuthSchemeCasing(t *testing.T) {
+	r := jett.New()
+
+	r.Use(BasicAuth("jett", map[string]string{"admin": "secret"}))
+
+	r.GET("/", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	credentials := base64.StdEncoding.EncodeToString([]byte("admin:secret"))
+
+	for _, scheme := range []string{"Basic", "basic", "BASIC", "bAsIc"} {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", scheme+" "+credentials)
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("middleware.BasicAuth -> %s scheme Expected : %d, Output : %d", scheme, http.StatusOK, res.StatusCode)
+		}
+	}
+}
+
+func TestMiddlewareNormalizeAuthScheme(t *testing.T) {
+	r := jett.New()
+
+	r.Use(NormalizeAuthScheme)
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		jett.Text(w, req.Header.Get("Authorization"), http.StatusOK)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		auth     string
+		expected string
+	}{
+		{"bearer abc.def", "Bearer abc.def"},
+		{"BeArEr abc.def", "Bearer abc.def"},
+		{"Bearer abc.def", "Bearer abc.def"},
+		{"custom abc.def", "custom abc.def"},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", test.auth)
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if output := string(body); output != test.expected {
+			t.Fatalf("middleware.NormalizeAuthScheme -> %q Expected : %s, Output : %s", test.auth, test.expected, output)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Request headers that content negotiation is based on
+var negotiationHeaders = []string{"Accept", "Accept-Encoding", "Accept-Language"}
+
+// AutoVary is a middleware that appends Accept, Accept-Encoding and Accept-Language
+// to the Vary response header, so that caches store a separate representation for each
+// negotiated variant (eg. when the response is negotiated with jett.Render).
+//
+// They're added whether or not the request carries them - a response negotiated for a
+// client without an Accept header is still only valid for clients that don't send one.
+//
+// Values already present in Vary (set by the handler or other middleware) are kept
+// and aren't duplicated.
+func AutoVary(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		hw := &headerHookWriter{ResponseWriter: w}
+		hw.beforeHeader = func() {
+			addVary(w.Header(), negotiationHeaders...)
+		}
+
+		next.ServeHTTP(hw, req)
+
+		// Nothing was written by the handler
+		if !hw.wroteHeader {
+			addVary(w.Header(), negotiationHeaders...)
+		}
+	})
+}
+
+// Adds the given headers to Vary, skipping those already present
+func addVary(h http.Header, headers ...string) {
+	existing := make(map[string]bool)
+	for _, value := range h["Vary"] {
+		for _, field := range strings.Split(value, ",") {
+			existing[strings.ToLower(strings.TrimSpace(field))] = true
+		}
+	}
+
+	if existing["*"] {
+		return
+	}
+
+	for _, header := range headers {
+		if !existing[strings.ToLower(header)] {
+			h.Add("Vary", header)
+			existing[strings.ToLower(header)] = true
+		}
+	}
+}
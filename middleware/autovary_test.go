@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareAutoVary(t *testing.T) {
+	r := jett.New()
+
+	r.Use(AutoVary)
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Vary", "Accept-Encoding")
+		jett.JSON(w, "ok", 200)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	// Without negotiation headers the response still varies on them
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{"negotiated", map[string]string{"Accept": "application/json", "Accept-Encoding": "gzip", "Accept-Language": "en"}},
+		{"no Accept headers", nil},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, value := range test.headers {
+			req.Header.Set(key, value)
+		}
+
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		expected := []string{"Accept-Encoding", "Accept", "Accept-Language"}
+		if !reflect.DeepEqual(res.Header["Vary"], expected) {
+			t.Fatalf("middleware.AutoVary %s -> Expected : %v, Output : %v", test.name, expected, res.Header["Vary"])
+		}
+	}
+}
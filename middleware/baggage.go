@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/saurabh0719/jett"
+)
+
+// Baggage is a middleware that collects the request headers starting with prefix
+// (eg. Baggage-Tenant-Id with prefix "Baggage-") as key-value baggage to propagate
+// through the request's call chain, OpenTelemetry style.
+// Handlers read it with jett.Baggage, keyed by the header name without the prefix (eg. Tenant-Id).
+//
+// Use BaggageTransport to re-emit the baggage on outbound requests.
+func Baggage(prefix string) func(next http.Handler) http.Handler {
+	prefix = http.CanonicalHeaderKey(prefix)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var baggage map[string]string
+
+			for name, values := range req.Header {
+				if !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+					continue
+				}
+				if baggage == nil {
+					baggage = make(map[string]string)
+				}
+				baggage[name[len(prefix):]] = values[0]
+			}
+
+			if baggage == nil {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			next.ServeHTTP(w, req.WithContext(jett.WithBaggage(req.Context(), baggage)))
+		})
+	}
+}
+
+// BaggageTransport returns an http.RoundTripper that adds the baggage carried by an outbound
+// request's context (see Baggage) as headers with the given prefix. base defaults to
+// http.DefaultTransport -
+//
+//	client := &http.Client{Transport: middleware.BaggageTransport("Baggage-", nil)}
+//
+//	out, _ := http.NewRequestWithContext(req.Context(), "GET", url, nil)
+//	client.Do(out)
+func BaggageTransport(prefix string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return roundTripperFunc(func(out *http.Request) (*http.Response, error) {
+		baggage := jett.Baggage(out)
+		if len(baggage) == 0 {
+			return base.RoundTrip(out)
+		}
+
+		// RoundTrippers must not modify the request
+		out = out.Clone(out.Context())
+		for key, value := range baggage {
+			out.Header.Set(prefix+key, value)
+		}
+
+		return base.RoundTrip(out)
+	})
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareBaggage(t *testing.T) {
+	// Downstream service echoing the baggage it receives
+	downstream := jett.New()
+	downstream.Use(Baggage("Baggage-"))
+	downstream.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		jett.JSON(w, jett.Baggage(req), http.StatusOK)
+	})
+
+	ds := httptest.NewServer(downstream)
+	defer ds.Close()
+
+	client := &http.Client{Transport: BaggageTransport("Baggage-", nil)}
+
+	r := jett.New()
+	r.Use(Baggage("baggage-"))
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		out, err := http.NewRequestWithContext(req.Context(), "GET", ds.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := client.Do(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		var received map[string]string
+		json.NewDecoder(res.Body).Decode(&received)
+
+		jett.JSON(w, map[string]map[string]string{
+			"local":      jett.Baggage(req),
+			"downstream": received,
+		}, http.StatusOK)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Baggage-Tenant-Id", "42")
+	req.Header.Set("baggage-region", "eu")
+	req.Header.Set("X-Other", "ignored")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	var output map[string]map[string]string
+	json.Unmarshal(body, &output)
+
+	expected := map[string]string{"Tenant-Id": "42", "Region": "eu"}
+
+	if !reflect.DeepEqual(output["local"], expected) {
+		t.Fatalf("middleware.Baggage -> Expected : %v, Output : %v", expected, output["local"])
+	}
+
+	if !reflect.DeepEqual(output["downstream"], expected) {
+		t.Fatalf("middleware.BaggageTransport -> Expected : %v, Output : %v", expected, output["downstream"])
+	}
+}
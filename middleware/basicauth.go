@@ -18,6 +18,9 @@ import (
 func BasicAuth(realm string, credentials map[string]string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			// The scheme is case-insensitive, eg. `authorization: basic ...`
+			normalizeAuthScheme(req.Header)
+
 			username, password, ok := req.BasicAuth()
 			if !ok {
 				unauthorized(w, realm)
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// JSONBodyLimit is a middleware that limits the size of JSON request bodies
+// (application/json, application/*+json) to maxBytes. Other content types pass through untouched,
+// so that JSON parsing can be held to a tighter limit than eg. file uploads -
+//
+//	r.Use(middleware.JSONBodyLimit(1 << 20))
+//	r.Use(middleware.MultipartBodyLimit(32 << 20))
+//
+// Requests that declare a larger Content-Length are rejected with 413 Request Entity Too Large,
+// otherwise reading past the limit fails with an error in the handler.
+func JSONBodyLimit(maxBytes int64) func(next http.Handler) http.Handler {
+	return bodyLimit(maxBytes, func(mediaType string) bool {
+		return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+	})
+}
+
+// MultipartBodyLimit is a middleware that limits the size of multipart/* request bodies to maxBytes.
+// Works like JSONBodyLimit.
+func MultipartBodyLimit(maxBytes int64) func(next http.Handler) http.Handler {
+	return bodyLimit(maxBytes, func(mediaType string) bool {
+		return strings.HasPrefix(mediaType, "multipart/")
+	})
+}
+
+// Applies the limit to requests whose media type matches
+func bodyLimit(maxBytes int64, match func(mediaType string) bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+			if req.Body == nil || err != nil || !match(mediaType) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			if req.ContentLength > maxBytes {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			req.Body = http.MaxBytesReader(w, req.Body, maxBytes)
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
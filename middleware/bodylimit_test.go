@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareBodyLimit(t *testing.T) {
+	r := jett.New()
+
+	r.Use(JSONBodyLimit(16))
+	r.Use(MultipartBodyLimit(1 << 10))
+
+	r.POST("/", func(w http.ResponseWriter, req *http.Request) {
+		if _, err := ioutil.ReadAll(req.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	// Multipart body well over the JSON limit, but under its own
+	var form bytes.Buffer
+	mw := multipart.NewWriter(&form)
+	mw.WriteField("name", strings.Repeat("jett", 64))
+	mw.Close()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		status      int
+	}{
+		{"json under limit", "application/json", []byte(`{"name":"jett"}`), http.StatusOK},
+		{"json over limit", "application/json", []byte(`{"name":"jett framework"}`), http.StatusRequestEntityTooLarge},
+		{"multipart under limit", mw.FormDataContentType(), form.Bytes(), http.StatusOK},
+		{"multipart over limit", mw.FormDataContentType(), bytes.Repeat(form.Bytes(), 8), http.StatusRequestEntityTooLarge},
+	}
+
+	for _, test := range tests {
+		res, err := http.Post(ts.URL, test.contentType, bytes.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.BodyLimit -> %s Expected : %d, Output : %d", test.name, test.status, res.StatusCode)
+		}
+	}
+}
+
+func TestMiddlewareBodyLimitChunked(t *testing.T) {
+	r := jett.New()
+
+	r.Use(JSONBodyLimit(16))
+
+	r.POST("/", func(w http.ResponseWriter, req *http.Request) {
+		if _, err := ioutil.ReadAll(req.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	// No Content-Length, so the limit is enforced while reading
+	body := ioutil.NopCloser(strings.NewReader(`{"name":"jett framework"}`))
+	req, err := http.NewRequest("POST", ts.URL, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("middleware.BodyLimit chunked -> Expected : %d, Output : %d", http.StatusRequestEntityTooLarge, res.StatusCode)
+	}
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+)
+
+// BufferResponse is a middleware that buffers response bodies of up to maxBytes
+// to set the Content-Length header, instead of falling back to chunked encoding
+// (net/http only does this for very small responses).
+//
+// Once a response grows over maxBytes (or the handler flushes it) the buffered part is
+// written out and the rest is streamed as usual.
+func BufferResponse(maxBytes int64) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			bw := &bufferResponseWriter{ResponseWriter: w, max: maxBytes}
+
+			next.ServeHTTP(bw, req)
+
+			if bw.streaming {
+				return
+			}
+
+			if bw.status == 0 {
+				bw.status = http.StatusOK
+			}
+
+			if bodyAllowed(bw.status) && w.Header().Get("Content-Length") == "" {
+				w.Header().Set("Content-Length", strconv.Itoa(bw.buf.Len()))
+			}
+
+			w.WriteHeader(bw.status)
+			bw.buf.WriteTo(w)
+		})
+	}
+}
+
+// Statuses that may have a body (RFC 7230, Section 3.3)
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// Buffers the response until it grows over max, then streams it
+type bufferResponseWriter struct {
+	http.ResponseWriter
+	max       int64
+	buf       bytes.Buffer
+	status    int
+	streaming bool
+}
+
+func (bw *bufferResponseWriter) WriteHeader(code int) {
+	if bw.streaming {
+		bw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if bw.status == 0 {
+		bw.status = code
+	}
+}
+
+func (bw *bufferResponseWriter) Write(buf []byte) (int, error) {
+	if !bw.streaming && int64(bw.buf.Len()+len(buf)) > bw.max {
+		if err := bw.stream(); err != nil {
+			return 0, err
+		}
+	}
+
+	if bw.streaming {
+		return bw.ResponseWriter.Write(buf)
+	}
+	return bw.buf.Write(buf)
+}
+
+// Implement http.Flusher interface so streaming handlers keep working
+func (bw *bufferResponseWriter) Flush() {
+	if !bw.streaming {
+		bw.stream()
+	}
+	if f, ok := bw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Writes out the status and the buffered body, further writes go straight through
+func (bw *bufferResponseWriter) stream() error {
+	bw.streaming = true
+
+	if bw.status == 0 {
+		bw.status = http.StatusOK
+	}
+	bw.ResponseWriter.WriteHeader(bw.status)
+
+	_, err := bw.buf.WriteTo(bw.ResponseWriter)
+	return err
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareBufferResponse(t *testing.T) {
+	r := jett.New()
+
+	r.Use(BufferResponse(16 << 10))
+
+	// Larger than net/http's own buffer, which would otherwise use chunked encoding
+	small := strings.Repeat("j", 8<<10)
+	large := strings.Repeat("j", 32<<10)
+
+	r.GET("/small", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		for i := 0; i < len(small); i += 1024 {
+			w.Write([]byte(small[i : i+1024]))
+		}
+	})
+
+	r.GET("/large", func(w http.ResponseWriter, req *http.Request) {
+		for i := 0; i < len(large); i += 1024 {
+			w.Write([]byte(large[i : i+1024]))
+		}
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path          string
+		status        int
+		body          string
+		contentLength string
+	}{
+		{"/small", http.StatusCreated, small, strconv.Itoa(len(small))},
+		{"/large", http.StatusOK, large, ""},
+	}
+
+	for _, test := range tests {
+		res, err := http.Get(ts.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.BufferResponse %s status -> Expected : %d, Output : %d", test.path, test.status, res.StatusCode)
+		}
+
+		if string(body) != test.body {
+			t.Fatalf("middleware.BufferResponse %s -> Expected a body of length %d, Output : %d", test.path, len(test.body), len(body))
+		}
+
+		if output := res.Header.Get("Content-Length"); output != test.contentLength {
+			t.Fatalf("middleware.BufferResponse %s Content-Length -> Expected : %q, Output : %q", test.path, test.contentLength, output)
+		}
+	}
+}
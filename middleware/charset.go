@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// Charset is a middleware that honours the Accept-Charset request header for legacy clients.
+// Text responses (text/*) written as UTF-8 are transcoded to the preferred charset supported by
+// golang.org/x/text/encoding (eg. iso-8859-1, us-ascii, shift_jis) and the charset parameter of
+// the Content-Type is updated. Characters that can't be represented are replaced with the charset's
+// replacement character.
+// JSON responses (application/json, application/*+json) stay UTF-8 as JSON requires, with
+// non-ASCII characters escaped (\u00e9) so they're readable by clients expecting another charset.
+//
+// Responds with 406 Not Acceptable when none of the requested charsets are supported.
+// Text and JSON responses are buffered in memory to be transcoded, other responses
+// (including text/event-stream) are written as is.
+func Charset(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		accept := req.Header.Get("Accept-Charset")
+		if accept == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		charset, enc, ok := negotiateCharset(accept)
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+			return
+		}
+
+		if enc == unicode.UTF8 {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		cw := &charsetWriter{ResponseWriter: w}
+		next.ServeHTTP(cw, req)
+
+		if !cw.buffered {
+			return
+		}
+
+		body := cw.buf.Bytes()
+
+		mediaType, params, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+		if strings.HasPrefix(mediaType, "text/") {
+			if transcoded, err := encoding.ReplaceUnsupported(enc.NewEncoder()).Bytes(body); err == nil {
+				body = transcoded
+				params["charset"] = charset
+				w.Header().Set("Content-Type", mime.FormatMediaType(mediaType, params))
+			}
+		} else {
+			body = escapeJSON(body)
+		}
+		w.Header().Del("Content-Length")
+
+		w.WriteHeader(cw.status)
+		w.Write(body)
+	})
+}
+
+// Buffers text and JSON responses (see isTextMediaType) so they can be transcoded,
+// other responses are written straight through. Decided once the header is written.
+type charsetWriter struct {
+	http.ResponseWriter
+	buf         bytes.Buffer
+	status      int
+	wroteHeader bool
+	buffered    bool
+}
+
+func (cw *charsetWriter) WriteHeader(code int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = code
+
+	contentType := cw.Header().Get("Content-Type")
+	cw.buffered = isTextMediaType(contentType) && !strings.HasPrefix(contentType, "text/event-stream")
+	if !cw.buffered {
+		cw.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (cw *charsetWriter) Write(buf []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.buffered {
+		return cw.buf.Write(buf)
+	}
+	return cw.ResponseWriter.Write(buf)
+}
+
+// Implement http.Flusher interface so streaming handlers keep working.
+// Buffered responses are only written once the handler returns.
+func (cw *charsetWriter) Flush() {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.buffered {
+		return
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Implement http.Pusher interface so HTTP/2 server push keeps working
+func (cw *charsetWriter) Push(target string, opts *http.PushOptions) error {
+	if pusher, ok := cw.ResponseWriter.(http.Pusher); ok {
+		return pusher.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Returns the wrapped http.ResponseWriter, for http.ResponseController
+func (cw *charsetWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Picks the supported charset with the highest quality from an Accept-Charset header.
+// Returns its name for the Content-Type charset parameter and its encoding.
+func negotiateCharset(accept string) (string, encoding.Encoding, bool) {
+	type candidate struct {
+		charset string
+		q       float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		charset := strings.ToLower(strings.TrimSpace(fields[0]))
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		if q > 0 {
+			candidates = append(candidates, candidate{charset, q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	for _, c := range candidates {
+		if c.charset == "*" {
+			return "utf-8", unicode.UTF8, true
+		}
+
+		enc, err := ianaindex.IANA.Encoding(c.charset)
+		if err != nil || enc == nil {
+			continue
+		}
+		if name, err := ianaindex.MIME.Name(enc); err == nil {
+			return strings.ToLower(name), enc, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// Escapes the non-ASCII characters of UTF-8 JSON as \uXXXX (surrogate pairs above U+FFFF).
+// They can only appear within strings, so the JSON stays equivalent.
+func escapeJSON(text []byte) []byte {
+	out := make([]byte, 0, len(text))
+	for len(text) > 0 {
+		r, size := utf8.DecodeRune(text)
+		text = text[size:]
+
+		switch {
+		case r < utf8.RuneSelf:
+			out = append(out, byte(r))
+		case r > 0xFFFF:
+			r1, r2 := utf16.EncodeRune(r)
+			out = append(out, fmt.Sprintf("\\u%04x\\u%04x", r1, r2)...)
+		default:
+			out = append(out, fmt.Sprintf("\\u%04x", r)...)
+		}
+	}
+	return out
+}
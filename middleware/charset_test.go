@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareCharset(t *testing.T) {
+	r := jett.New()
+
+	r.Use(Charset)
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("café ☕"))
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		acceptCharset string
+		status        int
+		contentType   string
+		body          []byte
+	}{
+		{"iso-8859-1", http.StatusCreated, "text/plain; charset=iso-8859-1", []byte("caf\xe9 \x1a")},
+		{"latin1", http.StatusCreated, "text/plain; charset=iso-8859-1", []byte("caf\xe9 \x1a")},
+		{"utf-8;q=0.5, us-ascii", http.StatusCreated, "text/plain; charset=us-ascii", []byte("caf\x1a \x1a")},
+		{"shift_jis", http.StatusCreated, "text/plain; charset=shift_jis", []byte("caf\x1a \x1a")},
+		{"utf-8", http.StatusCreated, "text/plain; charset=utf-8", []byte("café ☕")},
+		{"", http.StatusCreated, "text/plain; charset=utf-8", []byte("café ☕")},
+		{"utf-7, x-klingon", http.StatusNotAcceptable, "", nil},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if test.acceptCharset != "" {
+			req.Header.Set("Accept-Charset", test.acceptCharset)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.Charset -> %q Expected : %d, Output : %d", test.acceptCharset, test.status, res.StatusCode)
+		}
+
+		if test.status == http.StatusNotAcceptable {
+			continue
+		}
+
+		if output := res.Header.Get("Content-Type"); output != test.contentType {
+			t.Fatalf("middleware.Charset Content-Type -> %q Expected : %s, Output : %s", test.acceptCharset, test.contentType, output)
+		}
+
+		if !bytes.Equal(body, test.body) {
+			t.Fatalf("middleware.Charset body -> %q Expected : %q, Output : %q", test.acceptCharset, test.body, body)
+		}
+	}
+}
+
+func TestMiddlewareCharsetNonText(t *testing.T) {
+	r := jett.New()
+
+	r.Use(Charset)
+
+	r.GET("/json", func(w http.ResponseWriter, req *http.Request) {
+		jett.JSON(w, "café 😀", http.StatusOK)
+	})
+
+	r.GET("/binary", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte("caf\xc3\xa9"))
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path        string
+		contentType string
+		body        string
+	}{
+		// JSON stays UTF-8, non-ASCII characters are escaped
+		{"/json", "application/json", `"caf\u00e9 \ud83d\ude00"`},
+		// Other responses aren't transcoded
+		{"/binary", "application/octet-stream", "caf\xc3\xa9"},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", ts.URL+test.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept-Charset", "us-ascii")
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if output := res.Header.Get("Content-Type"); output != test.contentType {
+			t.Fatalf("middleware.Charset %s Content-Type -> Expected : %s, Output : %s", test.path, test.contentType, output)
+		}
+
+		if string(body) != test.body {
+			t.Fatalf("middleware.Charset %s body -> Expected : %q, Output : %q", test.path, test.body, body)
+		}
+	}
+}
+
+func TestMiddlewareCharsetSSE(t *testing.T) {
+	r := jett.New()
+
+	r.Use(Charset)
+
+	r.GET("/events", func(w http.ResponseWriter, req *http.Request) {
+		events := make(chan string, 1)
+		events <- "café"
+		close(events)
+		jett.SSE(w, req, events)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, err := http.NewRequest("GET", ts.URL+"/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept-Charset", "iso-8859-1")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	// Streamed through untouched
+	if res.StatusCode != http.StatusOK || string(body) != "data: café\n\n" {
+		t.Fatalf("middleware.Charset SSE -> Expected : %d %q, Output : %d %q", http.StatusOK, "data: café\n\n", res.StatusCode, body)
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// CheckOrigin is a middleware that validates the Origin header of WebSocket upgrade
+// requests against the allowed origins (eg. "https://example.com"), responding with
+// 403 Forbidden when it doesn't match, before the upgrade handler runs.
+//
+// Origins are compared case-insensitively. Requests without an Origin header
+// (non-browser clients) and requests that aren't upgrades pass through.
+func CheckOrigin(allowed ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			origin := req.Header.Get("Origin")
+
+			if origin != "" && isWebSocketUpgrade(req) && !originAllowed(origin, allowed) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// Checks for `Connection: Upgrade` and `Upgrade: websocket`
+func isWebSocketUpgrade(req *http.Request) bool {
+	if !strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
+		return false
+	}
+
+	for _, value := range req.Header["Connection"] {
+		for _, token := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func originAllowed(origin string, allowed []string) bool {
+	for _, item := range allowed {
+		if strings.EqualFold(origin, item) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareCheckOrigin(t *testing.T) {
+	r := jett.New()
+
+	r.Use(CheckOrigin("https://example.com"))
+
+	r.GET("/ws", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		origin  string
+		upgrade bool
+		status  int
+	}{
+		{"https://example.com", true, http.StatusOK},
+		{"https://evil.com", true, http.StatusForbidden},
+		{"https://evil.com", false, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", ts.URL+"/ws", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Origin", test.origin)
+		if test.upgrade {
+			req.Header.Set("Connection", "keep-alive, Upgrade")
+			req.Header.Set("Upgrade", "websocket")
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.CheckOrigin -> %s (upgrade: %t) Expected : %d, Output : %d", test.origin, test.upgrade, test.status, res.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CollapseSlashes is a middleware that collapses repeated slashes in the path into one,
+// eg. /users//jett/ -> /users/jett/. Unlike full path cleaning, dot segments (.. and .)
+// and percent-encoded sequences are left untouched.
+//
+// With redirect, the client is redirected to the collapsed path (preserving the query string),
+// GET & HEAD requests with 301 Moved Permanently and other methods with 308 Permanent Redirect.
+// Otherwise the path is rewritten in place before the request is routed.
+//
+// Rewriting has to happen before routing, so add it with r.Pre -
+//
+//	r.Pre(middleware.CollapseSlashes(false))
+func CollapseSlashes(redirect bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			path := req.URL.EscapedPath()
+			if !strings.Contains(path, "//") {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			collapsed := collapseSlashes(path)
+
+			if redirect {
+				if req.URL.RawQuery != "" {
+					collapsed += "?" + req.URL.RawQuery
+				}
+
+				code := http.StatusMovedPermanently
+				if req.Method != http.MethodGet && req.Method != http.MethodHead {
+					code = http.StatusPermanentRedirect
+				}
+
+				// Not http.Redirect, it would also clean the dot segments
+				w.Header().Set("Location", collapsed)
+				w.WriteHeader(code)
+				return
+			}
+
+			// Collapsed from the escaped path so encoded slashes (%2F) aren't collapsed
+			path, err := url.PathUnescape(collapsed)
+			if err != nil {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			req = req.Clone(req.Context())
+			req.URL.Path = path
+			req.URL.RawPath = collapsed
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// Replaces every run of slashes with a single one
+func collapseSlashes(path string) string {
+	var b strings.Builder
+	b.Grow(len(path))
+
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' && i > 0 && path[i-1] == '/' {
+			continue
+		}
+		b.WriteByte(path[i])
+	}
+
+	return b.String()
+}
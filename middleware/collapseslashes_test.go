@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareCollapseSlashes(t *testing.T) {
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	echoPath := func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(req.URL.EscapedPath()))
+	}
+
+	// Redirect
+	r := jett.New()
+	r.Pre(CollapseSlashes(true))
+	r.GET("/users/:name/*rest", echoPath)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	res, err := client.Get(ts.URL + "/users//jett///a%2F%2Fb/../c?page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	expected := "/users/jett/a%2F%2Fb/../c?page=2"
+	if res.StatusCode != http.StatusMovedPermanently || res.Header.Get("Location") != expected {
+		t.Fatalf("middleware.CollapseSlashes redirect -> Expected : 301 %s, Output : %d %s", expected, res.StatusCode, res.Header.Get("Location"))
+	}
+
+	// Rewrite in place
+	r = jett.New()
+	r.Pre(CollapseSlashes(false))
+	r.GET("/users/:name/*rest", echoPath)
+
+	ts2 := httptest.NewServer(r)
+	defer ts2.Close()
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/users//jett//posts", "/users/jett/posts"},
+		{"/users/jett/a%2F%2Fb//c", "/users/jett/a%2F%2Fb/c"},
+		{"/users/jett/posts", "/users/jett/posts"},
+	}
+
+	for _, test := range tests {
+		res, err := client.Get(ts2.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK || string(body) != test.expected {
+			t.Fatalf("middleware.CollapseSlashes %s -> Expected : 200 %s, Output : %d %s", test.path, test.expected, res.StatusCode, body)
+		}
+	}
+}
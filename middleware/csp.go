@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// CSPReportOnly is a middleware that sets the Content-Security-Policy-Report-Only header,
+// so that violations of the policy are reported to reportURI instead of being blocked.
+// Useful to tune a policy before enforcing it.
+//
+// Reports can be collected with Router.MountCSPReports -
+//
+//	r.Use(middleware.CSPReportOnly("default-src 'self'", "/csp-reports"))
+//	r.MountCSPReports("/csp-reports", func(report []byte) { log.Print(string(report)) })
+func CSPReportOnly(policy, reportURI string) func(next http.Handler) http.Handler {
+	header := policy
+	if reportURI != "" {
+		header += "; report-uri " + reportURI
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Security-Policy-Report-Only", header)
+			next.ServeHTTP(w, req)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareCSPReportOnly(t *testing.T) {
+	r := jett.New()
+
+	r.Use(CSPReportOnly("default-src 'self'", "/csp-reports"))
+
+	r.GET("/", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	expected := "default-src 'self'; report-uri /csp-reports"
+	if output := res.Header.Get("Content-Security-Policy-Report-Only"); output != expected {
+		t.Fatalf("middleware.CSPReportOnly -> Expected : %s, Output : %s", expected, output)
+	}
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DedupeStore keeps track of the delivery IDs seen by Dedupe.
+// Implement it to share deliveries across instances (eg. with Redis).
+type DedupeStore interface {
+	// Seen records the id and reports whether it was already recorded within the window
+	Seen(id string, window time.Duration) bool
+
+	// Forget removes the id so that a retried delivery is processed again
+	Forget(id string)
+}
+
+// Dedupe is a middleware that drops duplicate webhook deliveries.
+// The delivery ID is read from the given header (eg. X-GitHub-Delivery) and
+// deliveries with an ID already seen within the window are answered with
+// 200 OK without calling the handler. Requests without the header pass through.
+//
+// If the handler fails (5xx) the ID is forgotten so the provider's retry gets processed.
+//
+// IDs are kept in memory, use DedupeWithStore to plug in a shared store.
+func Dedupe(header string, window time.Duration) func(next http.Handler) http.Handler {
+	return DedupeWithStore(header, window, NewMemoryDedupeStore())
+}
+
+// DedupeWithStore is Dedupe with a custom DedupeStore
+func DedupeWithStore(header string, window time.Duration, store DedupeStore) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			id := req.Header.Get(header)
+			if id == "" {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			if store.Seen(id, window) {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			wrapped := wrapWriter(w)
+			next.ServeHTTP(wrapped, req)
+
+			if wrapped.Status() >= http.StatusInternalServerError {
+				store.Forget(id)
+			}
+		})
+	}
+}
+
+// In-memory DedupeStore
+type memoryDedupeStore struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewMemoryDedupeStore returns a DedupeStore that keeps IDs in memory.
+// Expired IDs are pruned as new ones are recorded.
+func NewMemoryDedupeStore() DedupeStore {
+	return &memoryDedupeStore{
+		seen: make(map[string]time.Time),
+	}
+}
+
+func (s *memoryDedupeStore) Seen(id string, window time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	if expiry, ok := s.seen[id]; ok && now.Before(expiry) {
+		return true
+	}
+
+	// Prune expired IDs
+	for key, expiry := range s.seen {
+		if !now.Before(expiry) {
+			delete(s.seen, key)
+		}
+	}
+
+	s.seen[id] = now.Add(window)
+	return false
+}
+
+func (s *memoryDedupeStore) Forget(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.seen, id)
+}
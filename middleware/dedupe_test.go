@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareDedupe(t *testing.T) {
+	header := "X-Delivery-ID"
+	processed := 0
+
+	r := jett.New()
+
+	r.Use(Dedupe(header, 100*time.Millisecond))
+
+	r.POST("/webhook", func(w http.ResponseWriter, req *http.Request) {
+		processed++
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	deliver := func(id string) int {
+		req, err := http.NewRequest("POST", ts.URL+"/webhook", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(header, id)
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	// First delivery and a duplicate within the window
+	if status := deliver("abc"); status != http.StatusAccepted {
+		t.Fatalf("middleware.Dedupe -> Expected : %d, Output : %d", http.StatusAccepted, status)
+	}
+	if status := deliver("abc"); status != http.StatusOK || processed != 1 {
+		t.Fatalf("middleware.Dedupe -> Expected duplicate to be dropped, Output : %d, processed %d times", status, processed)
+	}
+
+	// Same delivery outside the window
+	time.Sleep(150 * time.Millisecond)
+
+	if status := deliver("abc"); status != http.StatusAccepted || processed != 2 {
+		t.Fatalf("middleware.Dedupe -> Expected delivery outside the window to be processed, Output : %d, processed %d times", status, processed)
+	}
+}
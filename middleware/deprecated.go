@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// Deprecated is a middleware that warns clients of the upcoming removal of the routes it wraps.
+// Adds the Deprecation, Sunset (the removal date) & Link (migration docs) headers to every response
+// without changing its behaviour -
+//
+//	r.GET("/v1/users", usersV1, middleware.Deprecated(sunset, "https://example.com/docs/v2"))
+//
+// The Link header is skipped when link is empty.
+func Deprecated(sunset time.Time, link string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+
+			if link != "" {
+				w.Header().Add("Link", "<"+link+`>; rel="deprecation"`)
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
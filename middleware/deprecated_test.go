@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareDeprecated(t *testing.T) {
+	r := jett.New()
+
+	sunset := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	deprecated := Deprecated(sunset, "https://example.com/docs/v2")
+
+	r.GET("/v1", deprecated(http.HandlerFunc(handler)).ServeHTTP)
+	r.GET("/v2", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	expected := map[string]string{
+		"Deprecation": "true",
+		"Sunset":      "Tue, 01 Jan 2030 00:00:00 GMT",
+		"Link":        `<https://example.com/docs/v2>; rel="deprecation"`,
+	}
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("middleware.Deprecated status -> Expected : %d, Output : %d", http.StatusOK, res.StatusCode)
+	}
+
+	for key, value := range expected {
+		if output := res.Header.Get(key); output != value {
+			t.Fatalf("middleware.Deprecated %s -> Expected : %s, Output : %s", key, value, output)
+		}
+	}
+
+	res, err = http.Get(ts.URL + "/v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if output := res.Header.Get("Deprecation"); output != "" {
+		t.Fatalf("middleware.Deprecated unwrapped route -> Expected : %q, Output : %q", "", output)
+	}
+}
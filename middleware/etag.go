@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ETag is a middleware that sets an ETag on successful GET responses (hash of the body)
+// and responds with 304 Not Modified when it matches If-None-Match.
+// Set weak to emit weak ETags (W/"..."), eg. for CDNs that modify the body (compression).
+//
+// If-None-Match is matched with the weak comparison (RFC 7232, Section 3.2), so W/"x" matches "x".
+// ETags set by the handler are kept. Responses are buffered in memory to be hashed.
+// HEAD requests are passed through, as handlers usually don't write a body to hash for them.
+func ETag(weak bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method != http.MethodGet {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			bw := &bufferedWriter{ResponseWriter: w}
+			next.ServeHTTP(bw, req)
+
+			if bw.status == 0 {
+				bw.status = http.StatusOK
+			}
+
+			if bw.status == http.StatusOK {
+				etag := w.Header().Get("ETag")
+				if etag == "" {
+					h := fnv.New64a()
+					h.Write(bw.buf.Bytes())
+
+					etag = `"` + strconv.FormatUint(h.Sum64(), 16) + `"`
+					if weak {
+						etag = "W/" + etag
+					}
+					w.Header().Set("ETag", etag)
+				}
+
+				if etagMatch(req.Header.Get("If-None-Match"), etag) {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+
+			w.WriteHeader(bw.status)
+			bw.buf.WriteTo(w)
+		})
+	}
+}
+
+// Reports whether etag matches any of the ETags in the list (eg. an If-None-Match header),
+// using the weak comparison (RFC 7232, Section 2.3.2) which ignores the W/ prefix.
+func etagMatch(list, etag string) bool {
+	if strings.TrimSpace(list) == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareETag(t *testing.T) {
+	for _, weak := range []bool{true, false} {
+		r := jett.New()
+
+		r.Use(ETag(weak))
+
+		hello := func(w http.ResponseWriter, req *http.Request) {
+			jett.Text(w, "Hello", http.StatusOK)
+		}
+		r.GET("/", hello)
+		r.HEAD("/", hello)
+
+		ts := httptest.NewServer(r)
+
+		res, err := http.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		etag := res.Header.Get("ETag")
+		if etag == "" || strings.HasPrefix(etag, "W/") != weak {
+			t.Fatalf("middleware.ETag -> weak: %t Output : %q", weak, etag)
+		}
+
+		// Both the ETag itself and its weak/strong counterpart match with the weak comparison
+		opaque := strings.TrimPrefix(etag, "W/")
+		for _, ifNoneMatch := range []string{etag, opaque, "W/" + opaque, `"other", ` + etag} {
+			req, err := http.NewRequest("GET", ts.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("If-None-Match", ifNoneMatch)
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			if res.StatusCode != http.StatusNotModified {
+				t.Fatalf("middleware.ETag -> If-None-Match %s Expected : %d, Output : %d", ifNoneMatch, http.StatusNotModified, res.StatusCode)
+			}
+		}
+
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("If-None-Match", `W/"other"`)
+
+		res, err = http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("middleware.ETag -> mismatch Expected : %d, Output : %d", http.StatusOK, res.StatusCode)
+		}
+
+		res, err = http.Head(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK || res.Header.Get("ETag") != "" {
+			t.Fatalf("middleware.ETag -> HEAD Expected : %d without ETag, Output : %d %q", http.StatusOK, res.StatusCode, res.Header.Get("ETag"))
+		}
+
+		ts.Close()
+	}
+}
+
+func TestETagComparison(t *testing.T) {
+	// RFC 7232, Section 2.3.2
+	tests := []struct {
+		a, b  string
+		match bool
+	}{
+		{`W/"1"`, `W/"1"`, true},
+		{`W/"1"`, `W/"2"`, false},
+		{`W/"1"`, `"1"`, true},
+		{`"1"`, `"1"`, true},
+		{`*`, `"1"`, true},
+	}
+
+	for _, test := range tests {
+		if output := etagMatch(test.a, test.b); output != test.match {
+			t.Fatalf("etagMatch %s %s -> Expected : %t, Output : %t", test.a, test.b, test.match, output)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// ExclusiveParams is a middleware that rejects requests carrying more than one
+// query parameter from the same group with 400 Bad Request -
+//
+//	// ?id= xor ?name=
+//	r.GET("/users", FindUser, middleware.ExclusiveParams([]string{"id", "name"}))
+//
+// Parameters count as present even with an empty value (eg. ?id=&name=jett).
+func ExclusiveParams(groups ...[]string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			query := req.URL.Query()
+
+			for _, group := range groups {
+				present := 0
+				for _, param := range group {
+					if _, ok := query[param]; ok {
+						present++
+					}
+				}
+
+				if present > 1 {
+					http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
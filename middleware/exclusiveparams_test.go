@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareExclusiveParams(t *testing.T) {
+	r := jett.New()
+
+	r.Use(ExclusiveParams([]string{"id", "name"}, []string{"after", "before"}))
+
+	r.GET("/", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		query  string
+		status int
+	}{
+		{"?id=42", http.StatusOK},
+		{"?name=jett&after=10", http.StatusOK},
+		{"", http.StatusOK},
+		{"?id=42&name=jett", http.StatusBadRequest},
+		{"?id=42&after=1&before=5", http.StatusBadRequest},
+		{"?id=&name=", http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		res, err := http.Get(ts.URL + "/" + test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.ExclusiveParams -> %q Expected : %d, Output : %d", test.query, test.status, res.StatusCode)
+		}
+	}
+}
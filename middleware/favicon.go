@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Favicon is a middleware that serves the given icon bytes for `/favicon.ico`
+// before the request reaches any route handler (or the Logger).
+// Responds with 204 No Content when data is nil.
+//
+// Since Use() only wraps registered routes, wrap the whole router to cover
+// unregistered paths as well -
+//
+//	http.ListenAndServe(":8000", middleware.Favicon(icon)(r))
+func Favicon(data []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/favicon.ico" || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			if data == nil {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			// Icons rarely change, let clients cache them for a day
+			w.Header().Set("Content-Type", "image/x-icon")
+			w.Header().Set("Cache-Control", "public, max-age=86400")
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.WriteHeader(http.StatusOK)
+
+			if req.Method == http.MethodGet {
+				w.Write(data)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareFavicon(t *testing.T) {
+	icon := []byte{0x00, 0x00, 0x01, 0x00}
+
+	r := jett.New()
+	r.GET("/", handler)
+
+	ts := httptest.NewServer(Favicon(icon)(r))
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/favicon.ico")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("middleware.Favicon -> Expected : %d, Output : %d", http.StatusOK, res.StatusCode)
+	}
+
+	if !bytes.Equal(body, icon) {
+		t.Fatalf("middleware.Favicon -> Expected : %v, Output : %v", icon, body)
+	}
+
+	if ct := res.Header.Get("Content-Type"); ct != "image/x-icon" {
+		t.Fatalf("middleware.Favicon Content-Type -> Expected : image/x-icon, Output : %s", ct)
+	}
+
+	if res.Header.Get("Cache-Control") == "" {
+		t.Fatalf("middleware.Favicon -> Expected a Cache-Control header")
+	}
+}
+
+func TestMiddlewareFaviconNil(t *testing.T) {
+	r := jett.New()
+
+	ts := httptest.NewServer(Favicon(nil)(r))
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/favicon.ico")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("middleware.Favicon -> Expected : %d, Output : %d", http.StatusNoContent, res.StatusCode)
+	}
+}
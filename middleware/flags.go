@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/saurabh0719/jett"
+)
+
+// FlagProvider evaluates feature flags for a request, eg. based on the user or a cookie
+type FlagProvider interface {
+	Flags(req *http.Request) map[string]bool
+}
+
+// FlagProviderFunc is an adapter to allow the use of ordinary functions as a FlagProvider
+type FlagProviderFunc func(req *http.Request) map[string]bool
+
+// Flags calls f(req)
+func (f FlagProviderFunc) Flags(req *http.Request) map[string]bool {
+	return f(req)
+}
+
+// Flags is a middleware that evaluates feature flags once per request with the provider.
+// Handlers read the decisions with jett.Flag -
+//
+//	if jett.Flag(req, "new_checkout") { ... }
+//
+// The evaluated flags are also recorded in the request's log line (as flags=name:value,...)
+// when used along with Logger.
+func Flags(provider FlagProvider) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			flags := provider.Flags(req)
+
+			if len(flags) > 0 {
+				jett.LogField(req, "flags", formatFlags(flags))
+			}
+
+			next.ServeHTTP(w, req.WithContext(jett.WithFlags(req.Context(), flags)))
+		})
+	}
+}
+
+// Sorted name:value pairs
+func formatFlags(flags map[string]bool) string {
+	pairs := make([]string, 0, len(flags))
+	for name, on := range flags {
+		pairs = append(pairs, name+":"+strconv.FormatBool(on))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareFlags(t *testing.T) {
+	r := jett.New()
+
+	provider := FlagProviderFunc(func(req *http.Request) map[string]bool {
+		return map[string]bool{
+			"new_checkout": req.Header.Get("X-Beta") == "1",
+			"dark_mode":    false,
+		}
+	})
+
+	r.Use(Logger, Flags(provider))
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		if jett.Flag(req, "new_checkout") {
+			jett.Text(w, "new", http.StatusOK)
+			return
+		}
+		jett.Text(w, "old", http.StatusOK)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	for _, beta := range []string{"1", ""} {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Beta", beta)
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		expected := "old"
+		if beta == "1" {
+			expected = "new"
+		}
+
+		if string(body) != expected {
+			t.Fatalf("middleware.Flags -> X-Beta %q Expected : %s, Output : %s", beta, expected, body)
+		}
+	}
+
+	if !strings.Contains(buf.String(), "flags=dark_mode:false,new_checkout:true") {
+		t.Fatalf("middleware.Flags -> Expected evaluated flags in logs, Output : %s", buf.String())
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/saurabh0719/jett"
+)
+
+var forwardedChainKey = jett.NewContextKey[[]string]("forwardedChain")
+
+// ForwardedChain is a middleware that parses the full X-Forwarded-For list
+// and stores the proxy chain in the request context, followed by the immediate peer.
+//
+// Eg. X-Forwarded-For: 203.0.113.1, 198.51.100.2 from peer 10.0.0.1
+//
+// Result - [203.0.113.1 198.51.100.2 10.0.0.1]
+//
+// Malformed entries (anything that isn't an IP, with an optional port) are dropped.
+// The chain is logged by the Logger and can be read with GetForwardedChain.
+//
+// Please note that X-Forwarded-For is set by clients and proxies alike -
+// only the entries appended by your own proxies can be trusted.
+func ForwardedChain(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var chain []string
+
+		for _, xff := range req.Header["X-Forwarded-For"] {
+			for _, hop := range strings.Split(xff, ",") {
+				if ip := parseHop(hop); ip != "" {
+					chain = append(chain, ip)
+				}
+			}
+		}
+
+		if ip := parseHop(req.RemoteAddr); ip != "" {
+			chain = append(chain, ip)
+		}
+
+		ctx := forwardedChainKey.Set(req.Context(), chain)
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// GetForwardedChain returns the proxy chain from the given context if one is present.
+// Returns nil if the chain cannot be found.
+func GetForwardedChain(ctx context.Context) []string {
+	chain, _ := forwardedChainKey.Get(ctx)
+	return chain
+}
+
+// Parses a single hop - ip, ip:port or [ipv6]:port.
+// Returns the empty string when the hop is malformed.
+func parseHop(hop string) string {
+	hop = strings.TrimSpace(hop)
+
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		hop = host
+	}
+
+	ip := net.ParseIP(strings.Trim(hop, "[]"))
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareForwardedChain(t *testing.T) {
+	var chain []string
+
+	r := jett.New()
+
+	r.Use(ForwardedChain, Logger)
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		chain = GetForwardedChain(req.Context())
+		jett.JSON(w, chain, 200)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Forwarded-For", "203.0.113.1, not-an-ip, [2001:db8::1]:8080,198.51.100.2")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	expected := []string{"203.0.113.1", "2001:db8::1", "198.51.100.2", "127.0.0.1"}
+	if !reflect.DeepEqual(chain, expected) {
+		t.Fatalf("middleware.ForwardedChain -> Expected : %v, Output : %v", expected, chain)
+	}
+
+	logged := strings.Join(expected, ", ")
+	if !strings.Contains(buf.String(), logged) {
+		t.Fatalf("middleware.Logger -> Expected chain %q in logs, Output : %s", logged, buf.String())
+	}
+}
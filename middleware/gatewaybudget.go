@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// GatewayBudget is a middleware that reads the remaining time budget (in milliseconds)
+// passed by an upstream gateway and sets it as the request context deadline,
+// so handlers respect the upstream's timeout.
+//
+// Eg. middleware.GatewayBudget("X-Envoy-Expected-Rq-Timeout-Ms", 60*time.Second)
+//
+// The budget is clamped to maxBudget. Requests with a missing or invalid
+// header are passed through without a deadline.
+//
+// Like Timeout, handlers need to select on ctx.Done() for the deadline to have any effect.
+//
+// Panics if maxBudget isn't positive.
+func GatewayBudget(header string, maxBudget time.Duration) func(next http.Handler) http.Handler {
+	if maxBudget <= 0 {
+		panic("middleware: GatewayBudget maxBudget must be positive")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ms, err := strconv.ParseInt(req.Header.Get(header), 10, 64)
+			if err != nil || ms <= 0 {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			budget := maxBudget
+			if ms < int64(maxBudget/time.Millisecond) {
+				budget = time.Duration(ms) * time.Millisecond
+			}
+
+			ctx, cancel := context.WithTimeout(req.Context(), budget)
+			defer cancel()
+
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
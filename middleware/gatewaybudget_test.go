@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareGatewayBudget(t *testing.T) {
+	header := "X-Envoy-Expected-Rq-Timeout-Ms"
+
+	var deadline time.Time
+	var hasDeadline bool
+
+	r := jett.New()
+
+	r.Use(GatewayBudget(header, 30*time.Second))
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		deadline, hasDeadline = req.Context().Deadline()
+		jett.JSON(w, "ok", 200)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		budget   string
+		expected time.Duration
+	}{
+		{"1500", 1500 * time.Millisecond},
+		{"999999999", 30 * time.Second},
+		{"", 0},
+		{"soon", 0},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.budget != "" {
+			req.Header.Set(header, test.budget)
+		}
+
+		start := time.Now()
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if test.expected == 0 {
+			if hasDeadline {
+				t.Fatalf("middleware.GatewayBudget -> %q Expected no deadline, Output : %s", test.budget, deadline)
+			}
+			continue
+		}
+
+		remaining := deadline.Sub(start)
+		if !hasDeadline || remaining < test.expected || remaining > test.expected+time.Second {
+			t.Fatalf("middleware.GatewayBudget -> %q Expected : ~%s, Output : %s", test.budget, test.expected, remaining)
+		}
+	}
+}
+
+func TestMiddlewareGatewayBudgetInvalidMax(t *testing.T) {
+	for _, maxBudget := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("middleware.GatewayBudget %s -> Expected a panic", maxBudget)
+				}
+			}()
+
+			GatewayBudget("X-Envoy-Expected-Rq-Timeout-Ms", maxBudget)
+		}()
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+var idempotencyKeyHeader = "Idempotency-Key"
+
+// RequireIdempotencyKey is a middleware that enforces the presence of a well formed
+// Idempotency-Key header on unsafe methods (POST, PUT, PATCH & DELETE).
+// The key must be a UUID (eg. 123e4567-e89b-12d3-a456-426614174000).
+//
+// Responds with 400 Bad Request when the key is missing or malformed.
+// Safe methods (GET, HEAD, OPTIONS ...) pass through untouched.
+//
+// This middleware only validates the key - it does not store responses or
+// replay them for repeated keys.
+func RequireIdempotencyKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if isUnsafeMethod(req.Method) {
+			key := req.Header.Get(idempotencyKeyHeader)
+			if key == "" || !isUUID(key) {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// Methods that can change state on the server
+func isUnsafeMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// Checks for the canonical 8-4-4-4-12 hex format of a UUID
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			isHex := (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+			if !isHex {
+				return false
+			}
+		}
+	}
+
+	return true
+}
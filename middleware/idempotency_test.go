@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareRequireIdempotencyKey(t *testing.T) {
+	r := jett.New()
+
+	r.Use(RequireIdempotencyKey)
+
+	r.POST("/", handler)
+	r.GET("/", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		method string
+		key    string
+		status int
+	}{
+		{"POST", "123e4567-e89b-12d3-a456-426614174000", http.StatusOK},
+		{"POST", "", http.StatusBadRequest},
+		{"POST", "123e4567-e89b-12d3-a456", http.StatusBadRequest},
+		{"POST", "123e4567xe89b-12d3-a456-42661417400z", http.StatusBadRequest},
+		{"GET", "", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest(test.method, ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if test.key != "" {
+			req.Header.Set("Idempotency-Key", test.key)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.RequireIdempotencyKey -> %s %q Expected : %d, Output : %d", test.method, test.key, test.status, res.StatusCode)
+		}
+	}
+}
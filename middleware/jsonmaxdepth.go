@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// JSONMaxDepth is a middleware that rejects JSON request bodies (application/json, application/*+json)
+// nested deeper than maxDepth objects/arrays with 400 Bad Request, before a handler gets to decode them.
+// {"a":[1]} has a depth of 2.
+//
+// The body is buffered in memory and scanned without decoding it, so use it along with
+// JSONBodyLimit. Other content types pass through untouched.
+func JSONMaxDepth(maxDepth int) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+			if req.Body == nil || err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			body, err := ioutil.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+
+			if jsonDepthExceeds(body, maxDepth) {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+
+			// Restore the body for downstream handlers
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// Reports whether the objects/arrays in data are nested deeper than maxDepth.
+// Brackets inside strings are skipped, invalid JSON is left for the handler to report.
+func jsonDepthExceeds(data []byte, maxDepth int) bool {
+	depth := 0
+	inString, escaped := false, false
+
+	for _, c := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			if depth > maxDepth {
+				return true
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareJSONMaxDepth(t *testing.T) {
+	r := jett.New()
+
+	r.Use(JSONMaxDepth(3))
+
+	r.POST("/", func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		w.Write(body)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"flat", "application/json", `{"name":"jett"}`, http.StatusOK},
+		{"at limit", "application/json", `{"a":[{"b":1}]}`, http.StatusOK},
+		{"brackets in strings", "application/json", `{"a":"[[[[{{{{\"["}`, http.StatusOK},
+		{"too deep", "application/json", `{"a":[{"b":[1]}]}`, http.StatusBadRequest},
+		{"too deep arrays", "application/problem+json", `[[[[[]]]]]`, http.StatusBadRequest},
+		{"not json", "text/plain", `[[[[[]]]]]`, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		res, err := http.Post(ts.URL, test.contentType, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.JSONMaxDepth %s -> Expected : %d, Output : %d", test.name, test.status, res.StatusCode)
+		}
+
+		if test.status == http.StatusOK && string(body) != test.body {
+			t.Fatalf("middleware.JSONMaxDepth %s -> Expected the handler to read : %s, Output : %s", test.name, test.body, body)
+		}
+	}
+}
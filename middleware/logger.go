@@ -1,10 +1,15 @@
 package middleware 
 
 import (
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/saurabh0719/jett"
 )
 
 // Wraps http.ResponseWriter to allow us to store Status Code
@@ -37,14 +42,60 @@ func (rw *responseWriter) WriteHeader(code int) {
 	return
 }
 
+// Implement http.Flusher so streaming handlers (jett.SSE, CSVStream etc.) work behind the Logger
+func (rw *responseWriter) Flush() {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Implement http.Pusher so HTTP/2 server push (jett.Push) works behind the Logger
+func (rw *responseWriter) Push(target string, opts *http.PushOptions) error {
+	if pusher, ok := rw.ResponseWriter.(http.Pusher); ok {
+		return pusher.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Returns the wrapped http.ResponseWriter, lets http.ResponseController reach
+// any other optional interface (deadlines, hijacking etc.) behind the Logger
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // A basic logger for Jett
 // Logs 
 // 	- RequestID (if available from RequestID middleware)
+// 	- Proxy chain (if available from ForwardedChain middleware)
 // 	- Method and Path 
 // 	- status code of response
 // 	- Duration of the request-response cycle 
+// 	- Custom fields added by handlers with jett.LogField
 func Logger(next http.Handler) http.Handler {
+	return logger(next, nil)
+}
+
+// SampledLogger is Logger for high load, it only logs 1 in every n requests
+// to reduce log volume. Server errors (status >= 500) are always logged.
+func SampledLogger(n int) func(next http.Handler) http.Handler {
+	var count uint64
+	sample := func() bool {
+		return n <= 1 || (atomic.AddUint64(&count, 1)-1)%uint64(n) == 0
+	}
+
+	return func(next http.Handler) http.Handler {
+		return logger(next, sample)
+	}
+}
+
+// sample -> reports whether a request should be logged, nil logs every request
+func logger(next http.Handler, sample func() bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request){
+
+		sampled := sample == nil || sample()
 		
 		// Get unique requestID from request Context
 		requestID := GetRequestID(req.Context())
@@ -56,7 +107,16 @@ func Logger(next http.Handler) http.Handler {
 		} else {
 			start = "START RequestID: <nil>"
 		}
-		log.Print(start + " - " + req.Method + " " + req.URL.String())
+
+		// Proxy chain (if available from ForwardedChain middleware)
+		if chain := GetForwardedChain(req.Context()); len(chain) > 0 {
+			start += " - Forwarded: " + strings.Join(chain, ", ")
+		}
+
+		start += " - " + req.Method + " " + req.URL.String()
+		if sampled {
+			log.Print(start)
+		}
 
 		// register start time
 		t1 := time.Now()
@@ -64,6 +124,9 @@ func Logger(next http.Handler) http.Handler {
 		// Wrap http.ResponseWriter
 		wrapped := wrapWriter(w)
 
+		// Collect custom fields added by handlers with jett.LogField
+		req = req.WithContext(jett.WithLogFields(req.Context()))
+
 		// Call downstream handlers
 		next.ServeHTTP(wrapped, req)
 
@@ -83,12 +146,26 @@ func Logger(next http.Handler) http.Handler {
 		d := t2.Sub(t1)
 		duration = "Duration: "  + d.String()
 
+		// Custom fields
+		for _, field := range jett.LogFields(req) {
+			duration += ", " + field.Key + "=" + fmt.Sprint(field.Value)
+		}
+
 		// Prepare final log with Status code
 		status := wrapped.Status()
+
+		// Requests that weren't sampled are only logged on server errors
+		if !sampled {
+			if status < 500 {
+				return
+			}
+			log.Print(start)
+		}
+
 		if status > 99 && status < 600 {
-			log.Printf(end + " - " + "Status: " + strconv.Itoa(status) + ", " + duration + "\n")
+			log.Print(end + " - " + "Status: " + strconv.Itoa(status) + ", " + duration + "\n")
 		} else {
-			log.Printf(end + " - " + duration + "\n")
+			log.Print(end + " - " + duration + "\n")
 		}
 		
 	})
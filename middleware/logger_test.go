@@ -0,0 +1,242 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+// Sends a request with `Expect: 100-continue` over a raw connection and returns
+// the status line received before the body is sent along with the final status line
+func expectContinue(t *testing.T, url string, contentLength string) (string, string) {
+	conn, err := net.Dial("tcp", strings.TrimPrefix(url, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	conn.Write([]byte("POST /upload HTTP/1.1\r\nHost: jett\r\nExpect: 100-continue\r\nContent-Length: " + contentLength + "\r\n\r\n"))
+
+	reader := bufio.NewReader(conn)
+	first, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(first, "100 Continue") {
+		return strings.TrimSpace(first), strings.TrimSpace(first)
+	}
+
+	// Server asked for the body, send it
+	reader.ReadString('\n')
+	conn.Write([]byte("hello"))
+
+	res, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	return strings.TrimSpace(first), res.Status
+}
+
+func TestMiddlewareLoggerExpectContinue(t *testing.T) {
+	r := jett.New()
+
+	r.Use(Logger)
+
+	r.POST("/upload", func(w http.ResponseWriter, req *http.Request) {
+		if jett.ExpectsContinue(req) && req.ContentLength > 5 {
+			jett.Text(w, "Upload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		jett.Text(w, string(body), http.StatusOK)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	first, final := expectContinue(t, ts.URL, "5")
+	if !strings.Contains(first, "100 Continue") || final != "200 OK" {
+		t.Fatalf("100-continue -> Expected : 100 Continue then 200 OK, Output : %s then %s", first, final)
+	}
+
+	first, _ = expectContinue(t, ts.URL, "1024")
+	if !strings.Contains(first, "413") {
+		t.Fatalf("100-continue -> Expected : 413 without 100 Continue, Output : %s", first)
+	}
+}
+
+func TestMiddlewareLoggerFields(t *testing.T) {
+	r := jett.New()
+
+	r.Use(Logger)
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		jett.LogField(req, "user_id", 42)
+		jett.LogField(req, "plan", "free")
+		jett.LogField(req, "plan", "pro")
+		jett.LogField(req, "discount", "100%")
+		jett.JSON(w, "ok", 200)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	res, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	// Field values aren't treated as format strings
+	if !strings.Contains(buf.String(), "user_id=42, plan=pro, discount=100%\n") {
+		t.Fatalf("middleware.Logger -> Expected custom fields in logs, Output : %s", buf.String())
+	}
+}
+
+func TestMiddlewareSampledLogger(t *testing.T) {
+	r := jett.New()
+
+	r.Use(SampledLogger(10))
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		jett.Text(w, "ok", http.StatusOK)
+	})
+	r.GET("/error", func(w http.ResponseWriter, req *http.Request) {
+		jett.Text(w, "error", http.StatusInternalServerError)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	for i := 0; i < 100; i++ {
+		path := "/"
+		if i%20 == 5 {
+			path = "/error"
+		}
+
+		res, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	logs := buf.String()
+
+	// 1 in 10 of the 100 requests, plus all 5 errors (none of which fall on a sampled request)
+	if output := strings.Count(logs, "END RequestID"); output != 15 {
+		t.Fatalf("middleware.SampledLogger -> Expected : %d requests logged, Output : %d", 15, output)
+	}
+
+	if output := strings.Count(logs, "Status: 500"); output != 5 {
+		t.Fatalf("middleware.SampledLogger -> Expected : %d errors logged, Output : %d", 5, output)
+	}
+
+	if output := strings.Count(logs, "START RequestID"); output != 15 {
+		t.Fatalf("middleware.SampledLogger -> Expected : %d START lines, Output : %d", 15, output)
+	}
+}
+
+// Records the targets pushed
+type pushRecorder struct {
+	*httptest.ResponseRecorder
+	pushed []string
+}
+
+func (pr *pushRecorder) Push(target string, opts *http.PushOptions) error {
+	pr.pushed = append(pr.pushed, target)
+	return nil
+}
+
+func TestMiddlewareLoggerPush(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	r := jett.New()
+
+	r.Use(Logger)
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		if err := jett.Push(w, "/static/styles.css", nil); err != nil {
+			t.Errorf("middleware.Logger push -> Expected : <nil>, Output : %v", err)
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Errorf("middleware.Logger push -> Expected the writer to implement http.Flusher too")
+			return
+		}
+		io.WriteString(w, "ok")
+		flusher.Flush()
+	})
+
+	w := &pushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if len(w.pushed) != 1 || w.pushed[0] != "/static/styles.css" {
+		t.Fatalf("middleware.Logger push -> Expected : [/static/styles.css], Output : %v", w.pushed)
+	}
+
+	if !w.Flushed || w.Body.String() != "ok" {
+		t.Fatalf("middleware.Logger push -> Expected : flushed ok, Output : %t %s", w.Flushed, w.Body.String())
+	}
+}
+
+func TestMiddlewareLoggerSSE(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	r := jett.New()
+
+	r.Use(Logger)
+
+	r.GET("/events", func(w http.ResponseWriter, req *http.Request) {
+		events := make(chan string, 2)
+		events <- "first"
+		events <- "second"
+		close(events)
+
+		jett.SSE(w, req, events)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("middleware.Logger SSE -> Expected : 200 text/event-stream, Output : %d %s", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+
+	expected := "data: first\n\ndata: second\n\n"
+	if string(body) != expected {
+		t.Fatalf("middleware.Logger SSE -> Expected : %q, Output : %q", expected, body)
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// LowercasePath is a middleware that redirects requests with uppercase characters
+// in the path to the lowercase equivalent, preserving the query string.
+// Eg. /Users/Jett?Page=2 -> /users/jett?Page=2
+//
+// Percent-encoded sequences are left untouched (%2F stays %2F) and leading slashes are
+// collapsed into one, so the redirect never leaves the host (//EVIL.com -> /evil.com).
+//
+// Like httprouter, GET & HEAD requests are redirected with 301 Moved Permanently
+// and all other methods with 308 Permanent Redirect so the method and body are preserved.
+func LowercasePath(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path := req.URL.EscapedPath()
+		lower := lowercaseEscaped(path)
+
+		if lower == path {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		// A leading // would be sent as a network-path reference (//evil.com/x),
+		// redirecting the client to another host
+		if strings.HasPrefix(lower, "//") {
+			lower = "/" + strings.TrimLeft(lower, "/")
+		}
+
+		if req.URL.RawQuery != "" {
+			lower += "?" + req.URL.RawQuery
+		}
+
+		code := http.StatusMovedPermanently
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			code = http.StatusPermanentRedirect
+		}
+
+		http.Redirect(w, req, lower, code)
+	})
+}
+
+// Lowercases an escaped path, skipping the hex digits of percent-encoded sequences
+func lowercaseEscaped(path string) string {
+	var buf []byte
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+
+		if c == '%' && i+2 < len(path) {
+			if buf != nil {
+				buf = append(buf, path[i:i+3]...)
+			}
+			i += 2
+			continue
+		}
+
+		if c >= 'A' && c <= 'Z' {
+			// Copy lazily, most paths are already lowercase
+			if buf == nil {
+				buf = make([]byte, i, len(path))
+				copy(buf, path[:i])
+			}
+			c += 'a' - 'A'
+		}
+
+		if buf != nil {
+			buf = append(buf, c)
+		}
+	}
+
+	if buf == nil {
+		return path
+	}
+	return string(buf)
+}
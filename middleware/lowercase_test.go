@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareLowercasePath(t *testing.T) {
+	r := jett.New()
+	r.GET("/users/:name", handler)
+
+	ts := httptest.NewServer(LowercasePath(r))
+	defer ts.Close()
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	res, err := client.Get(ts.URL + "/Users/Jett%2Fa%C3%89?Page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	expected := "/users/jett%2Fa%C3%89?Page=2"
+	if res.StatusCode != http.StatusMovedPermanently || res.Header.Get("Location") != expected {
+		t.Fatalf("middleware.LowercasePath -> Expected : 301 %s, Output : %d %s", expected, res.StatusCode, res.Header.Get("Location"))
+	}
+
+	res, err = client.Get(ts.URL + "/users/jett")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("middleware.LowercasePath -> Expected : 200, Output : %d", res.StatusCode)
+	}
+
+	// Leading slashes must not turn into a redirect to another host
+	res, err = client.Get(ts.URL + "//EVIL.com/x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusMovedPermanently || res.Header.Get("Location") != "/evil.com/x" {
+		t.Fatalf("middleware.LowercasePath -> Expected : 301 /evil.com/x, Output : %d %s", res.StatusCode, res.Header.Get("Location"))
+	}
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime"
+	"net/http"
+)
+
+const piiMask = "***"
+
+// Request bodies over this size are not logged, only a truncation marker is
+const maskPIIMaxBody = 64 << 10
+
+// MaskPII is a middleware that logs JSON request bodies with the given fields masked.
+// Eg. MaskPII("password", "ssn") logs {"user":"jett","password":"***"}
+//
+// Fields are matched by key at any depth of the JSON document.
+// Only the logged representation is masked - the handler still reads the original body.
+// Only request bodies are logged, responses are not. Bodies that aren't JSON are not logged,
+// and bodies over 64KB are logged as <truncated> (a partial document can't be masked reliably)
+// without being held in memory.
+func MaskPII(fields ...string) func(next http.Handler) http.Handler {
+	masked := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		masked[field] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+
+			if req.Body != nil && mediaType == "application/json" {
+				// Read at most one byte over the limit, the rest is left to stream to the handler
+				body, err := ioutil.ReadAll(io.LimitReader(req.Body, maskPIIMaxBody+1))
+				if err != nil {
+					req.Body.Close()
+					http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+					return
+				}
+
+				// Restore the original body for downstream handlers
+				req.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+
+				prefix := ""
+				if requestID := GetRequestID(req.Context()); requestID != "" {
+					prefix = "RequestID: " + requestID + " - "
+				}
+
+				if len(body) > maskPIIMaxBody {
+					log.Print(prefix + "Body: <truncated, over 64KB>")
+					next.ServeHTTP(w, req)
+					return
+				}
+
+				var doc interface{}
+				if err := json.Unmarshal(body, &doc); err != nil {
+					log.Print(prefix + "Body: <invalid json>")
+				} else {
+					maskedBody, _ := json.Marshal(maskFields(doc, masked))
+					log.Print(prefix + "Body: " + string(maskedBody))
+				}
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// Recursively replaces the values of masked keys
+func maskFields(doc interface{}, masked map[string]bool) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if masked[key] {
+				v[key] = piiMask
+			} else {
+				v[key] = maskFields(value, masked)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = maskFields(value, masked)
+		}
+	}
+	return doc
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareMaskPII(t *testing.T) {
+	var received map[string]string
+
+	r := jett.New()
+
+	r.Use(MaskPII("password"))
+
+	r.POST("/", func(w http.ResponseWriter, req *http.Request) {
+		json.NewDecoder(req.Body).Decode(&received)
+		jett.JSON(w, "ok", 200)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	body := `{"user":"jett","password":"hunter2"}`
+	res, err := http.Post(ts.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if received["password"] != "hunter2" {
+		t.Fatalf("middleware.MaskPII -> Expected handler to read : hunter2, Output : %s", received["password"])
+	}
+
+	logged := buf.String()
+	if strings.Contains(logged, "hunter2") || !strings.Contains(logged, `"password":"***"`) {
+		t.Fatalf("middleware.MaskPII -> Expected password to be masked in logs, Output : %s", logged)
+	}
+}
+
+func TestMiddlewareMaskPIILargeBody(t *testing.T) {
+	var received int
+
+	r := jett.New()
+
+	r.Use(MaskPII("password"))
+
+	r.POST("/", func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		received = len(body)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	body := `{"password":"hunter2","data":"` + strings.Repeat("a", 100<<10) + `"}`
+	res, err := http.Post(ts.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if received != len(body) {
+		t.Fatalf("middleware.MaskPII large body -> Expected handler to read : %d bytes, Output : %d", len(body), received)
+	}
+
+	logged := buf.String()
+	if strings.Contains(logged, "hunter2") || !strings.Contains(logged, "Body: <truncated, over 64KB>") {
+		t.Fatalf("middleware.MaskPII large body -> Expected a truncation marker, Output : %.200s", logged)
+	}
+}
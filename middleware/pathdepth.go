@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// MaxPathDepth is a middleware that responds with 404 Not Found to requests whose
+// path has more than n non-empty segments, eg. /a/b/c has a depth of 3.
+// Protects catch-all routes from deep traversal.
+//
+// Use it with Router.Pre to check the depth before routing -
+//
+//	r.Pre(middleware.MaxPathDepth(8))
+func MaxPathDepth(n int) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			depth := 0
+			for _, segment := range strings.Split(req.URL.Path, "/") {
+				if segment != "" {
+					depth++
+				}
+			}
+
+			if depth > n {
+				http.NotFound(w, req)
+				return
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareMaxPathDepth(t *testing.T) {
+	r := jett.New()
+
+	r.Pre(MaxPathDepth(3))
+
+	r.GET("/files/*filepath", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/files/a", http.StatusOK},
+		{"/files/a/b", http.StatusOK},
+		{"/files/a//b/", http.StatusOK},
+		{"/files/a/b/c", http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		res, err := http.Get(ts.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.MaxPathDepth -> %s Expected : %d, Output : %d", test.path, test.status, res.StatusCode)
+		}
+	}
+}
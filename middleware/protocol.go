@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// RequireHTTP2 is a middleware for endpoints that rely on HTTP/2 (eg. server push or multiplexing).
+// Requests made over HTTP/1.x are rejected with 426 Upgrade Required.
+func RequireHTTP2(next http.Handler) http.Handler {
+	return requireProtocol(2, 0, "h2", next)
+}
+
+// RequireHTTP11 is a middleware that rejects HTTP/1.0 requests with 426 Upgrade Required,
+// eg. for endpoints that rely on persistent connections or chunked responses.
+// HTTP/1.1 and HTTP/2 requests pass through.
+func RequireHTTP11(next http.Handler) http.Handler {
+	return requireProtocol(1, 1, "HTTP/1.1", next)
+}
+
+// Rejects requests made with a protocol version older than major.minor,
+// upgrade is sent in the Upgrade header as required for 426 responses (RFC 9110, Section 15.5.22)
+func requireProtocol(major, minor int, upgrade string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !req.ProtoAtLeast(major, minor) {
+			w.Header().Set("Upgrade", upgrade)
+			w.Header().Set("Connection", "Upgrade")
+			http.Error(w, http.StatusText(http.StatusUpgradeRequired), http.StatusUpgradeRequired)
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
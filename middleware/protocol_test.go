@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareRequireProtocol(t *testing.T) {
+	r := jett.New()
+
+	r.GET("/push", handler, RequireHTTP2)
+	r.GET("/stream", handler, RequireHTTP11)
+
+	tests := []struct {
+		path    string
+		proto   string
+		major   int
+		minor   int
+		status  int
+		upgrade string
+	}{
+		{"/push", "HTTP/1.1", 1, 1, http.StatusUpgradeRequired, "h2"},
+		{"/push", "HTTP/2.0", 2, 0, http.StatusOK, ""},
+		{"/stream", "HTTP/1.0", 1, 0, http.StatusUpgradeRequired, "HTTP/1.1"},
+		{"/stream", "HTTP/1.1", 1, 1, http.StatusOK, ""},
+		{"/stream", "HTTP/2.0", 2, 0, http.StatusOK, ""},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", test.path, nil)
+		req.Proto, req.ProtoMajor, req.ProtoMinor = test.proto, test.major, test.minor
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.status || w.Header().Get("Upgrade") != test.upgrade {
+			t.Fatalf("middleware.RequireHTTP %s %s -> Expected : %d %q, Output : %d %q", test.path, test.proto,
+				test.status, test.upgrade, w.Code, w.Header().Get("Upgrade"))
+		}
+	}
+}
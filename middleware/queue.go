@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// Queue is a middleware that smooths bursty traffic. It serves up to workers requests
+// concurrently and queues up to queueSize more, each waiting for up to maxWait.
+//
+// Responds with 503 Service Unavailable when the queue is full or a queued request
+// waited longer than maxWait (or the client went away).
+func Queue(workers, queueSize int, maxWait time.Duration) func(next http.Handler) http.Handler {
+	// pending -> requests being served or waiting in the queue
+	pending := make(chan struct{}, workers+queueSize)
+	active := make(chan struct{}, workers)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			select {
+			case pending <- struct{}{}:
+			default:
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+			defer func() { <-pending }()
+
+			timer := time.NewTimer(maxWait)
+			defer timer.Stop()
+
+			select {
+			case active <- struct{}{}:
+			case <-timer.C:
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			case <-req.Context().Done():
+				return
+			}
+			defer func() { <-active }()
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
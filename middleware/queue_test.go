@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareQueue(t *testing.T) {
+	r := jett.New()
+
+	started := make(chan struct{}, 4)
+	release := make(chan struct{})
+
+	r.Use(Queue(1, 1, 200*time.Millisecond))
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	get := func(statuses chan<- int) {
+		res, err := http.Get(ts.URL)
+		if err != nil {
+			statuses <- 0
+			return
+		}
+		res.Body.Close()
+		statuses <- res.StatusCode
+	}
+
+	// Admitted immediately
+	first := make(chan int, 1)
+	go get(first)
+	<-started
+
+	// Queued behind the first request
+	queued := make(chan int, 1)
+	go get(queued)
+	time.Sleep(50 * time.Millisecond)
+
+	// Queue is full
+	full := make(chan int, 1)
+	get(full)
+	if status := <-full; status != http.StatusServiceUnavailable {
+		t.Fatalf("middleware.Queue full -> Expected : %d, Output : %d", http.StatusServiceUnavailable, status)
+	}
+
+	// Free the worker, the queued request is served
+	release <- struct{}{}
+	<-started
+	release <- struct{}{}
+
+	for name, statuses := range map[string]chan int{"first": first, "queued": queued} {
+		if status := <-statuses; status != http.StatusOK {
+			t.Fatalf("middleware.Queue %s -> Expected : %d, Output : %d", name, http.StatusOK, status)
+		}
+	}
+
+	// Waits longer than maxWait
+	go get(first)
+	<-started
+
+	timedOut := make(chan int, 1)
+	start := time.Now()
+	get(timedOut)
+
+	if status := <-timedOut; status != http.StatusServiceUnavailable || time.Since(start) < 200*time.Millisecond {
+		t.Fatalf("middleware.Queue maxWait -> Expected : %d after 200ms, Output : %d after %s", http.StatusServiceUnavailable, status, time.Since(start))
+	}
+
+	release <- struct{}{}
+	<-first
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// ReadOnly is a middleware to put a router (or subrouter) in read-only mode,
+// eg. during a maintenance window.
+//
+// While the flag is set (non-zero), unsafe methods (POST, PUT, PATCH & DELETE)
+// are rejected with 503 Service Unavailable while GET, HEAD & OPTIONS are still served.
+// The flag is read atomically on every request so it can be toggled at runtime -
+//
+//	var readOnly int32
+//	r.Use(middleware.ReadOnly(&readOnly))
+//
+//	atomic.StoreInt32(&readOnly, 1) // enable
+//	atomic.StoreInt32(&readOnly, 0) // disable
+func ReadOnly(enabled *int32) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if atomic.LoadInt32(enabled) != 0 && isUnsafeMethod(req.Method) {
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareReadOnly(t *testing.T) {
+	var readOnly int32
+
+	r := jett.New()
+
+	r.Use(ReadOnly(&readOnly))
+
+	r.GET("/", handler)
+	r.POST("/", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		enabled int32
+		method  string
+		status  int
+	}{
+		{1, "POST", http.StatusServiceUnavailable},
+		{1, "GET", http.StatusOK},
+		{0, "POST", http.StatusOK},
+		{0, "GET", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		atomic.StoreInt32(&readOnly, test.enabled)
+
+		req, err := http.NewRequest(test.method, ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.ReadOnly -> enabled: %d, %s Expected : %d, Output : %d", test.enabled, test.method, test.status, res.StatusCode)
+		}
+	}
+}
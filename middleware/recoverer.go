@@ -4,6 +4,7 @@ package middleware
 // Source: https://github.com/zenazn/goji/blob/master/web/middleware/recoverer.go
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"runtime/debug"
@@ -12,6 +13,36 @@ import (
 // Simple recoverer middleware to recover from panics and print the debug stack.
 // Also sets status 500 to the ResponseWriter so no more writes take place
 func Recoverer(next http.Handler) http.Handler {
+	return recoverer(next, nil)
+}
+
+// RecovererWithErrors works like Recoverer, but panics with one of the given errors
+// (or an error wrapping it) respond with the mapped status instead of 500 -
+//
+//	r.Use(middleware.RecovererWithErrors(map[error]int{
+//		ErrNotFound: http.StatusNotFound,
+//	}))
+//
+// Only errors are mapped, other panic values fall back to 500.
+func RecovererWithErrors(statuses map[error]int) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return recoverer(next, statuses)
+	}
+}
+
+// Status for a recovered panic value, 500 unless it's a mapped error
+func panicStatus(recovered interface{}, statuses map[error]int) int {
+	if err, ok := recovered.(error); ok {
+		for target, status := range statuses {
+			if errors.Is(err, target) {
+				return status
+			}
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+func recoverer(next http.Handler, statuses map[error]int) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 
 		// Get unique requestID from request Context
@@ -28,8 +59,9 @@ func Recoverer(next http.Handler) http.Handler {
 				log.Printf("Panic : %+v", err)
 				debug.PrintStack()
 
-				// Internal server error; No more writes to this Writer
-				http.Error(w, http.StatusText(500), 500)
+				// Internal server error (or mapped status); No more writes to this Writer
+				status := panicStatus(err, statuses)
+				http.Error(w, http.StatusText(status), status)
 
 			}
 
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestMiddlewareRecovererWithErrors(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	r := jett.New()
+
+	r.Use(RecovererWithErrors(map[error]int{
+		errNotFound: http.StatusNotFound,
+	}))
+
+	r.GET("/mapped", func(w http.ResponseWriter, req *http.Request) {
+		panic(errNotFound)
+	})
+
+	r.GET("/wrapped", func(w http.ResponseWriter, req *http.Request) {
+		panic(fmt.Errorf("user 42: %w", errNotFound))
+	})
+
+	r.GET("/unmapped", func(w http.ResponseWriter, req *http.Request) {
+		panic("oops")
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/mapped", http.StatusNotFound},
+		{"/wrapped", http.StatusNotFound},
+		{"/unmapped", http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		res, err := http.Get(ts.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.RecovererWithErrors %s -> Expected : %d, Output : %d", test.path, test.status, res.StatusCode)
+		}
+	}
+}
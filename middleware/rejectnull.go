@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/saurabh0719/jett"
+)
+
+// RejectExplicitNull is a middleware for strict JSON APIs that rejects request bodies
+// setting any of the given (top-level) fields to an explicit null, eg. {"name": null}.
+// Absent fields pass through, so handlers can still tell them apart.
+//
+// Responds with 422 Unprocessable Entity and the offending fields as a jett.BindError.
+// The body is decoded twice (once here, once by the handler), it's buffered in memory
+// so use it along with a limit on the body size. Non JSON bodies (anything other than
+// application/json and application/*+json) pass through untouched.
+func RejectExplicitNull(fields ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+			if req.Body == nil || err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			body, err := ioutil.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+
+			// Restore the body for downstream handlers
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			// Invalid JSON is left for the handler to report
+			var object map[string]json.RawMessage
+			if json.Unmarshal(body, &object) != nil {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			bindErr := &jett.BindError{}
+			for _, field := range fields {
+				if value, ok := object[field]; ok && bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+					bindErr.Fields = append(bindErr.Fields, jett.FieldError{
+						Field:   field,
+						Message: "must not be null",
+					})
+				}
+			}
+
+			if len(bindErr.Fields) > 0 {
+				jett.DefaultErrorHandler(w, req, bindErr)
+				return
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareRejectExplicitNull(t *testing.T) {
+	r := jett.New()
+
+	r.Use(RejectExplicitNull("name", "email"))
+
+	r.POST("/", func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		w.Write(body)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		output      string
+	}{
+		{"absent", "application/json", `{"age":42}`, http.StatusOK, `{"age":42}`},
+		{"present", "application/json", `{"name":"jett","email":"a@b.c"}`, http.StatusOK, `{"name":"jett","email":"a@b.c"}`},
+		{"explicit null", "application/json", `{"name":null,"email":"a@b.c"}`, http.StatusUnprocessableEntity, `{"errors":[{"field":"name","message":"must not be null"}]}`},
+		{"explicit null +json", "application/merge-patch+json", `{"name":null}`, http.StatusUnprocessableEntity, `{"errors":[{"field":"name","message":"must not be null"}]}`},
+		{"other field null", "application/json", `{"age":null}`, http.StatusOK, `{"age":null}`},
+		{"text body", "text/plain", `{"name":null}`, http.StatusOK, `{"name":null}`},
+	}
+
+	for _, test := range tests {
+		res, err := http.Post(ts.URL, test.contentType, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != test.status || string(body) != test.output {
+			t.Fatalf("middleware.RejectExplicitNull %s -> Expected : %d %s, Output : %d %s", test.name, test.status, test.output, res.StatusCode, body)
+		}
+	}
+}
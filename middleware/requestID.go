@@ -12,10 +12,13 @@ import (
 	"os"
 	"strings"
 	"sync/atomic"
+
+	"github.com/saurabh0719/jett"
 )
 
 var defaultRequestIDHeader = "X-Request-ID"
-var prefix string
+var requestIDKey = jett.NewContextKey[string]("requestID")
+var prefix atomic.Value
 var reqid uint64
 
 // A quick note on the statistics here: we're trying to calculate the chance that
@@ -47,22 +50,45 @@ func init() {
 		b64 = strings.NewReplacer("+", "", "/", "").Replace(b64)
 	}
 
-	prefix = fmt.Sprintf("%s/%s", hostname, b64[0:10])
+	prefix.Store(fmt.Sprintf("%s/%s", hostname, b64[0:10]))
+}
+
+// SetRequestIDPrefix overrides the "host.example.com/random" portion of generated
+// request IDs, eg. to use a pod name or to get predictable IDs in tests.
+func SetRequestIDPrefix(requestIDPrefix string) {
+	prefix.Store(requestIDPrefix)
+}
+
+// ResetRequestIDCounter resets the counter of generated request IDs,
+// the next ID ends with 000001. Meant for tests.
+func ResetRequestIDCounter() {
+	atomic.StoreUint64(&reqid, 0)
+}
+
+// Generates the next request ID.
+// Requests carrying a seed (see TestSeed) get an ID derived from the seed alone,
+// so the same seed always produces the same ID.
+func newRequestID(ctx context.Context) string {
+	if seed, ok := GetTestSeed(ctx); ok {
+		return fmt.Sprintf("seed/%d", seed)
+	}
+
+	myid := atomic.AddUint64(&reqid, 1)
+	return fmt.Sprintf("%s-%06d", prefix.Load().(string), myid)
 }
 
 // RequestID is a middleware that injects a request ID into the context of each
 // request. A request ID is a string of the form "host.example.com/random-0001",
 // where "random" is a base62 random string that uniquely identifies this go
 // process, and where the last number is an atomically incremented request
-// counter.
+// counter. Under TestSeed (registered before RequestID) the ID is derived from the seed.
 func RequestID(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		requestID := req.Header.Get(defaultRequestIDHeader)
 		if requestID == "" {
-			myid := atomic.AddUint64(&reqid, 1)
-			requestID = fmt.Sprintf("%s-%06d", prefix, myid)
+			requestID = newRequestID(req.Context())
 		}
-		ctx := context.WithValue(req.Context(), "requestID", requestID)
+		ctx := requestIDKey.Set(req.Context(), requestID)
 		next.ServeHTTP(w, req.WithContext(ctx))
 	})
 }
@@ -75,10 +101,27 @@ func RequestIDFromCustomHeader(headerKey string) func(next http.Handler) http.Ha
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			requestID := req.Header.Get(headerKey)
 			if requestID == "" {
-				myid := atomic.AddUint64(&reqid, 1)
-				requestID = fmt.Sprintf("%s-%06d", prefix, myid)
+				requestID = newRequestID(req.Context())
 			}
-			ctx := context.WithValue(req.Context(), "requestID", requestID)
+			ctx := requestIDKey.Set(req.Context(), requestID)
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// RequireRequestID is a middleware for strict environments (eg. a service mesh) where every
+// request should already carry a request ID in the given header. Unlike RequestID it
+// never generates one - requests without the header are rejected with 400 Bad Request.
+// The ID is injected into the context and is accessible with GetRequestID.
+func RequireRequestID(headerKey string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			requestID := req.Header.Get(headerKey)
+			if requestID == "" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			ctx := requestIDKey.Set(req.Context(), requestID)
 			next.ServeHTTP(w, req.WithContext(ctx))
 		})
 	}
@@ -87,11 +130,6 @@ func RequestIDFromCustomHeader(headerKey string) func(next http.Handler) http.Ha
 // GetReqID returns a request ID from the given context if one is present.
 // Returns the empty string if a request ID cannot be found.
 func GetRequestID(ctx context.Context) string {
-	if ctx == nil {
-		return ""
-	}
-	if requestID, ok := ctx.Value("requestID").(string); ok {
-		return requestID
-	}
-	return ""
+	requestID, _ := requestIDKey.Get(ctx)
+	return requestID
 }
@@ -11,7 +11,7 @@ import (
 )
 
 func handler(w http.ResponseWriter, req *http.Request) {
-	reqID := req.Context().Value("requestID")
+	reqID := GetRequestID(req.Context())
 	jett.JSON(w, reqID, 200)
 }
 
@@ -50,3 +50,81 @@ func TestMiddlewareRequestIDWithCustomHeaderStrKey(t *testing.T) {
 		t.Fatalf("middleware.RequestID -> Expected : %s, Output : %s", headerValue, requestId)
 	}
 }
+
+func TestMiddlewareRequireRequestID(t *testing.T) {
+	var headerKey = "X-Request-ID"
+	r := jett.New()
+
+	r.Use(RequireRequestID(headerKey))
+
+	r.GET("/", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("middleware.RequireRequestID -> Expected : %d, Output : %d", http.StatusBadRequest, res.StatusCode)
+	}
+
+	req.Header.Set(headerKey, "mesh-12345")
+
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requestId string
+	json.Unmarshal(body, &requestId)
+
+	if res.StatusCode != http.StatusOK || requestId != "mesh-12345" {
+		t.Fatalf("middleware.RequireRequestID -> Expected : 200 mesh-12345, Output : %d %s", res.StatusCode, requestId)
+	}
+}
+
+func TestMiddlewareRequestIDPrefix(t *testing.T) {
+	SetRequestIDPrefix("test-host")
+	ResetRequestIDCounter()
+
+	r := jett.New()
+
+	r.Use(RequestID)
+
+	r.GET("/", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	for _, expected := range []string{"test-host-000001", "test-host-000002"} {
+		res, err := http.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		var requestId string
+		json.Unmarshal(body, &requestId)
+
+		if requestId != expected {
+			t.Fatalf("middleware.RequestID prefix -> Expected : %s, Output : %s", expected, requestId)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+)
+
+// RequireContentType is a middleware that catches handlers writing a response body
+// without setting the Content-Type header first (eg. setting it after WriteHeader, when it's
+// no longer sent). Logs a warning with the method and path of the request.
+//
+// If defaultType isn't empty, it is set as the Content-Type when the headers haven't been
+// written yet (body written without calling WriteHeader), otherwise net/http sniffs it.
+func RequireContentType(defaultType string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			cw := &contentTypeWriter{
+				ResponseWriter: w,
+				warn: func() {
+					log.Printf("RequireContentType - %s %s wrote a body without a Content-Type", req.Method, req.URL.Path)
+				},
+				defaultType: defaultType,
+			}
+
+			next.ServeHTTP(cw, req)
+		})
+	}
+}
+
+// Checks the Content-Type when the headers are written and on the first write of the body
+type contentTypeWriter struct {
+	http.ResponseWriter
+	warn        func()
+	defaultType string
+
+	wroteHeader    bool
+	hadContentType bool
+	checked        bool
+}
+
+func (cw *contentTypeWriter) WriteHeader(code int) {
+	if !cw.wroteHeader {
+		cw.wroteHeader = true
+		cw.hadContentType = cw.Header().Get("Content-Type") != ""
+	}
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+func (cw *contentTypeWriter) Write(buf []byte) (int, error) {
+	if !cw.checked && len(buf) > 0 {
+		cw.checked = true
+
+		if !cw.wroteHeader && cw.Header().Get("Content-Type") == "" {
+			cw.warn()
+			if cw.defaultType != "" {
+				cw.Header().Set("Content-Type", cw.defaultType)
+			}
+		} else if cw.wroteHeader && !cw.hadContentType {
+			cw.warn()
+		}
+	}
+
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	return cw.ResponseWriter.Write(buf)
+}
+
+// Implement http.Flusher interface so streaming handlers keep working
+func (cw *contentTypeWriter) Flush() {
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
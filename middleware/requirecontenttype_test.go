@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareRequireContentType(t *testing.T) {
+	r := jett.New()
+
+	r.Use(RequireContentType("application/octet-stream"))
+
+	r.GET("/missing", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("hello"))
+	})
+
+	// Content-Type set too late, it is never sent
+	r.GET("/late", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("hello"))
+	})
+
+	r.GET("/ok", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("hello"))
+	})
+
+	r.GET("/empty", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path        string
+		warning     bool
+		contentType string
+	}{
+		{"/missing", true, "application/octet-stream"},
+		{"/late", true, "text/plain; charset=utf-8"},
+		{"/ok", false, "text/plain"},
+		{"/empty", false, ""},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+
+		res, err := http.Get(ts.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		log.SetOutput(os.Stderr)
+
+		if warning := strings.Contains(buf.String(), "GET "+test.path+" wrote a body without a Content-Type"); warning != test.warning {
+			t.Fatalf("middleware.RequireContentType %s -> Expected warning : %t, Output : %q", test.path, test.warning, buf.String())
+		}
+
+		if output := res.Header.Get("Content-Type"); output != test.contentType {
+			t.Fatalf("middleware.RequireContentType %s Content-Type -> Expected : %q, Output : %q", test.path, test.contentType, output)
+		}
+	}
+}
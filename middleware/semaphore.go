@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Named semaphores shared by the routes using Semaphore
+var semaphores = struct {
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}{slots: make(map[string]chan struct{})}
+
+// Semaphore is a middleware that limits the number of requests served concurrently to max,
+// across all the routes using a semaphore with the same name. Eg. to cap expensive report
+// endpoints separately from cheap ones -
+//
+//	r.GET("/reports/daily", Daily, middleware.Semaphore("reports", 2))
+//	r.GET("/reports/monthly", Monthly, middleware.Semaphore("reports", 2))
+//
+// Responds with 503 Service Unavailable when all the slots are taken.
+// The semaphore is created by the first Semaphore call with a name, later calls share it
+// and must use the same max.
+//
+// Panics if max isn't positive or differs from the max of an existing semaphore with the same name.
+func Semaphore(name string, max int) func(next http.Handler) http.Handler {
+	if max <= 0 {
+		panic("middleware: Semaphore max must be positive")
+	}
+
+	semaphores.mu.Lock()
+	slots, ok := semaphores.slots[name]
+	if !ok {
+		slots = make(chan struct{}, max)
+		semaphores.slots[name] = slots
+	}
+	semaphores.mu.Unlock()
+
+	if cap(slots) != max {
+		panic(fmt.Sprintf("middleware: Semaphore %q already exists with max %d", name, cap(slots)))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			select {
+			case slots <- struct{}{}:
+			default:
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+			defer func() { <-slots }()
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
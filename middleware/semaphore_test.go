@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareSemaphore(t *testing.T) {
+	r := jett.New()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	r.GET("/reports/daily", func(w http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-release
+	}, Semaphore("test-reports", 1))
+	r.GET("/reports/monthly", handler, Semaphore("test-reports", 1))
+	r.GET("/export", handler, Semaphore("test-export", 1))
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	get := func(path string) int {
+		res, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Error(err)
+			return 0
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	// Holds the only slot of test-reports
+	done := make(chan int)
+	go func() {
+		done <- get("/reports/daily")
+	}()
+	<-started
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/reports/monthly", http.StatusServiceUnavailable},
+		{"/export", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		if status := get(test.path); status != test.status {
+			t.Fatalf("middleware.Semaphore %s -> Expected : %d, Output : %d", test.path, test.status, status)
+		}
+	}
+
+	close(release)
+	if status := <-done; status != http.StatusOK {
+		t.Fatalf("middleware.Semaphore /reports/daily -> Expected : %d, Output : %d", http.StatusOK, status)
+	}
+
+	// The slot is free again
+	if status := get("/reports/monthly"); status != http.StatusOK {
+		t.Fatalf("middleware.Semaphore /reports/monthly -> Expected : %d, Output : %d", http.StatusOK, status)
+	}
+}
+
+func TestMiddlewareSemaphoreInvalid(t *testing.T) {
+	Semaphore("test-invalid", 2)
+
+	tests := []struct {
+		name string
+		max  int
+	}{
+		{"test-invalid-zero", 0},
+		{"test-invalid-negative", -1},
+		// Conflicts with the existing semaphore's max
+		{"test-invalid", 10},
+	}
+
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("middleware.Semaphore %s %d -> Expected a panic", test.name, test.max)
+				}
+			}()
+
+			Semaphore(test.name, test.max)
+		}()
+	}
+
+	// The same max shares the semaphore
+	Semaphore("test-invalid", 2)
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/saurabh0719/jett"
+)
+
+// ServerTiming is a middleware for local performance debugging. Timings recorded by handlers
+// with jett.RecordTiming are sent in the Server-Timing response header, which shows up
+// in the browser's devtools -
+//
+//	r.Use(middleware.ServerTiming(os.Getenv("ENV") == "dev"))
+//
+//	start := time.Now()
+//	users := db.FindUsers()
+//	jett.RecordTiming(req, "db", time.Since(start))
+//
+// Timings must be recorded before the response is written. Disabled when enabled is false,
+// as timings can reveal details about the backend.
+func ServerTiming(enabled bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			req = req.WithContext(jett.WithTimings(req.Context()))
+
+			setHeader := func() {
+				if timings := jett.Timings(req); len(timings) > 0 {
+					w.Header().Set("Server-Timing", formatServerTiming(timings))
+				}
+			}
+
+			hw := &headerHookWriter{ResponseWriter: w, beforeHeader: setHeader}
+			next.ServeHTTP(hw, req)
+
+			// Nothing was written by the handler
+			if !hw.wroteHeader {
+				setHeader()
+			}
+		})
+	}
+}
+
+// eg. db;dur=12.5, cache;dur=0.8
+func formatServerTiming(timings []jett.Timing) string {
+	metrics := make([]string, len(timings))
+	for i, t := range timings {
+		ms := float64(t.Duration.Microseconds()) / 1000
+		metrics[i] = t.Name + ";dur=" + strconv.FormatFloat(ms, 'f', -1, 64)
+	}
+	return strings.Join(metrics, ", ")
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareServerTiming(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		r := jett.New()
+
+		r.Use(ServerTiming(enabled))
+
+		r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+			jett.RecordTiming(req, "db", 12500*time.Microsecond)
+			jett.RecordTiming(req, "cache", 800*time.Microsecond)
+			jett.Text(w, "ok", http.StatusOK)
+		})
+
+		ts := httptest.NewServer(r)
+
+		res, err := http.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		ts.Close()
+
+		expected := "db;dur=12.5, cache;dur=0.8"
+		if !enabled {
+			expected = ""
+		}
+
+		if output := res.Header.Get("Server-Timing"); output != expected {
+			t.Fatalf("middleware.ServerTiming -> enabled: %t Expected : %q, Output : %q", enabled, expected, output)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TenantRateLimit is a middleware for multi-tenant apps that gives every tenant its own
+// token bucket rate limit.
+// - tenantKey -> identifies the tenant of a request (eg. from a header or the auth token)
+// - limits -> the tenant's requests per second and burst, looked up when its bucket is created
+//
+// Responds with 429 Too Many Requests once a tenant exceeds its limit, with a Retry-After
+// of the time until its bucket has a token again plus random jitter, so that rejected
+// clients don't all retry at once.
+// Buckets of inactive tenants are dropped once they've refilled, so limits changed
+// by limits are picked up from then on.
+func TenantRateLimit(tenantKey func(req *http.Request) string, limits func(tenant string) (rps float64, burst int)) func(next http.Handler) http.Handler {
+	tl := &tenantLimiter{
+		buckets: make(map[string]*tokenBucket),
+		limits:  limits,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if ok, wait := tl.allow(tenantKey(req)); !ok {
+				if wait > 0 {
+					w.Header().Set("Retry-After", retryAfter(wait))
+				}
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// Token bucket, refilled at rate tokens per second up to burst
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// Adds the tokens earned since the last refill
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+// Time until the bucket has a token, 0 if it never refills
+func (b *tokenBucket) wait() time.Duration {
+	if b.rate <= 0 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// Retry-After value in seconds - the wait rounded up plus up to half of it
+// (at least a second) of random jitter to spread out retries
+func retryAfter(wait time.Duration) string {
+	seconds := int64(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	jitter := seconds / 2
+	if jitter < 1 {
+		jitter = 1
+	}
+
+	return strconv.FormatInt(seconds+rand.Int63n(jitter+1), 10)
+}
+
+type tenantLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	limits    func(tenant string) (float64, int)
+	lastPrune time.Time
+}
+
+// Takes a token from the tenant's bucket, reports whether there was one
+// and if not, how long until there is (0 if the bucket never refills)
+func (tl *tenantLimiter) allow(tenant string) (bool, time.Duration) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	now := time.Now()
+
+	// A full bucket is the same as a new one, so buckets that refilled can be dropped
+	if now.Sub(tl.lastPrune) > time.Minute {
+		for key, b := range tl.buckets {
+			if b.refill(now); b.tokens >= b.burst {
+				delete(tl.buckets, key)
+			}
+		}
+		tl.lastPrune = now
+	}
+
+	b, ok := tl.buckets[tenant]
+	if !ok {
+		rps, burst := tl.limits(tenant)
+		b = &tokenBucket{rate: rps, burst: float64(burst), tokens: float64(burst), last: now}
+		tl.buckets[tenant] = b
+	}
+
+	b.refill(now)
+	if b.tokens < 1 {
+		return false, b.wait()
+	}
+
+	b.tokens--
+	return true, 0
+}
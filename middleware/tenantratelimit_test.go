@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareTenantRateLimit(t *testing.T) {
+	r := jett.New()
+
+	r.Use(TenantRateLimit(func(req *http.Request) string {
+		return req.Header.Get("X-Tenant")
+	}, func(tenant string) (float64, int) {
+		if tenant == "enterprise" {
+			return 0.001, 5
+		}
+		return 0.001, 2
+	}))
+
+	r.GET("/", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	allowed := map[string]int{}
+
+	// Requests from both tenants interleaved
+	for i := 0; i < 6; i++ {
+		for _, tenant := range []string{"free", "enterprise"} {
+			req, err := http.NewRequest("GET", ts.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("X-Tenant", tenant)
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			switch res.StatusCode {
+			case http.StatusOK:
+				allowed[tenant]++
+			case http.StatusTooManyRequests:
+			default:
+				t.Fatalf("middleware.TenantRateLimit -> Unexpected status : %d", res.StatusCode)
+			}
+		}
+	}
+
+	if allowed["free"] != 2 || allowed["enterprise"] != 5 {
+		t.Fatalf("middleware.TenantRateLimit -> Expected : free 2 enterprise 5, Output : free %d enterprise %d", allowed["free"], allowed["enterprise"])
+	}
+}
+
+func TestMiddlewareTenantRateLimitRetryAfter(t *testing.T) {
+	r := jett.New()
+
+	// A token every 4 seconds
+	r.Use(TenantRateLimit(func(req *http.Request) string {
+		return "tenant"
+	}, func(tenant string) (float64, int) {
+		return 0.25, 1
+	}))
+
+	r.GET("/", handler)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	seen := map[int]bool{}
+
+	for i := 0; i < 50; i++ {
+		res, err := http.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if i == 0 {
+			continue
+		}
+
+		if res.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("middleware.TenantRateLimit Retry-After -> Expected : %d, Output : %d", http.StatusTooManyRequests, res.StatusCode)
+		}
+
+		// 4s refill plus up to 2s of jitter
+		retryAfter, err := strconv.Atoi(res.Header.Get("Retry-After"))
+		if err != nil || retryAfter < 4 || retryAfter > 6 {
+			t.Fatalf("middleware.TenantRateLimit Retry-After -> Expected : 4 - 6, Output : %s", res.Header.Get("Retry-After"))
+		}
+		seen[retryAfter] = true
+	}
+
+	if len(seen) < 2 {
+		t.Fatalf("middleware.TenantRateLimit Retry-After -> Expected jittered values, Output : %v", seen)
+	}
+}
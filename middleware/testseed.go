@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/saurabh0719/jett"
+)
+
+var testSeedKey = jett.NewContextKey[int64]("testSeed")
+
+// TestSeed is a middleware for reproducible load tests. It reads an integer seed from
+// the given header and stores it in the request context so that handlers can behave
+// deterministically, eg. rand.New(rand.NewSource(seed)). RequestID, registered after
+// TestSeed, generates the same request ID for the same seed.
+//
+// The header is only honoured when enabled is true, so it can't be abused in production -
+//
+//	r.Use(middleware.TestSeed("X-Test-Seed", os.Getenv("LOAD_TEST") == "1"))
+//
+// Invalid seeds are ignored.
+func TestSeed(header string, enabled bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			seed, err := strconv.ParseInt(req.Header.Get(header), 10, 64)
+			if err != nil {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			ctx := testSeedKey.Set(req.Context(), seed)
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// GetTestSeed returns the seed set by TestSeed from the given context.
+// ok is false if no seed is present.
+func GetTestSeed(ctx context.Context) (seed int64, ok bool) {
+	return testSeedKey.Get(ctx)
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareTestSeed(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		r := jett.New()
+
+		r.Use(TestSeed("X-Test-Seed", enabled))
+
+		r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+			seed, ok := GetTestSeed(req.Context())
+			if !ok {
+				seed = -1
+			}
+			jett.JSON(w, seed, 200)
+		})
+
+		ts := httptest.NewServer(r)
+
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Test-Seed", "1234")
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		ts.Close()
+
+		var seed int64
+		json.Unmarshal(body, &seed)
+
+		expected := int64(1234)
+		if !enabled {
+			expected = -1
+		}
+
+		if seed != expected {
+			t.Fatalf("middleware.TestSeed -> enabled: %t Expected : %d, Output : %d", enabled, expected, seed)
+		}
+	}
+}
+
+func TestMiddlewareTestSeedRequestID(t *testing.T) {
+	r := jett.New()
+
+	r.Use(TestSeed("X-Test-Seed", true), RequestID)
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		jett.Text(w, GetRequestID(req.Context()), 200)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	get := func(seed string) string {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seed != "" {
+			req.Header.Set("X-Test-Seed", seed)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		return string(body)
+	}
+
+	first, second := get("42"), get("42")
+	if first != second {
+		t.Fatalf("middleware.TestSeed RequestID -> Expected the same ID for the same seed, Output : %s, %s", first, second)
+	}
+
+	if other := get("7"); other == first {
+		t.Fatalf("middleware.TestSeed RequestID -> Expected a different ID for another seed, Output : %s", other)
+	}
+
+	// Without a seed IDs are unique again
+	if get("") == get("") {
+		t.Fatalf("middleware.TestSeed RequestID -> Expected unique IDs without a seed")
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ErrBodyReadTimeout is returned when reading a request body under TimeoutBody
+// blocks past the deadline
+var ErrBodyReadTimeout = errors.New("middleware: request body read timed out")
+
+// TimeoutBody is a middleware that works like Timeout but also aborts reading the request body.
+// The connection's read deadline is set to the timeout, so a handler blocked reading a slow body
+// (eg. a slow-loris upload) gets ErrBodyReadTimeout from Read once the deadline passes,
+// instead of waiting on the client. The connection can't be reused after an aborted read.
+//
+// The read deadline needs a ResponseWriter that supports it (net/http's does from Go 1.20,
+// middleware wrapping it must implement Unwrap), otherwise only the context deadline is set.
+func TimeoutBody(timeout time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			req = req.WithContext(ctx)
+
+			var body *deadlineBody
+			rd := readDeadliner(w)
+			if req.Body != nil && req.Body != http.NoBody && rd != nil && rd.SetReadDeadline(time.Now().Add(timeout)) == nil {
+				body = &deadlineBody{ReadCloser: req.Body}
+				req.Body = body
+			}
+
+			defer func() {
+				cancel()
+
+				timedOut := body != nil && body.timedOut
+				if body != nil && !timedOut {
+					// Clear it for the next request on the connection. Left as is after an
+					// aborted read, so the server doesn't wait on the rest of the body
+					rd.SetReadDeadline(time.Time{})
+				}
+
+				if timedOut || ctx.Err() == context.DeadlineExceeded {
+					w.WriteHeader(http.StatusGatewayTimeout)
+				}
+			}()
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// Returns the ResponseWriter (or the one it wraps) that can set the connection's read deadline,
+// like http.ResponseController does. nil if there is none
+func readDeadliner(w http.ResponseWriter) interface{ SetReadDeadline(time.Time) error } {
+	for {
+		if rd, ok := w.(interface{ SetReadDeadline(time.Time) error }); ok {
+			return rd
+		}
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return nil
+		}
+		w = u.Unwrap()
+	}
+}
+
+// Request body that reports reads cut off by the read deadline as ErrBodyReadTimeout
+type deadlineBody struct {
+	io.ReadCloser
+	timedOut bool
+}
+
+func (b *deadlineBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		b.timedOut = true
+		err = ErrBodyReadTimeout
+	}
+	return n, err
+}
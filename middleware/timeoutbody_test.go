@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareTimeoutBody(t *testing.T) {
+	r := jett.New()
+
+	r.Use(TimeoutBody(100 * time.Millisecond))
+
+	readErr := make(chan error, 1)
+	r.POST("/", func(w http.ResponseWriter, req *http.Request) {
+		_, err := ioutil.ReadAll(req.Body)
+		readErr <- err
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	// The client announces a 100 byte body, sends 4 and stalls
+	conn, err := net.Dial("tcp", ts.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	io.WriteString(conn, "POST / HTTP/1.1\r\nHost: jett\r\nContent-Length: 100\r\n\r\njett")
+
+	select {
+	case err := <-readErr:
+		if err != ErrBodyReadTimeout {
+			t.Fatalf("middleware.TimeoutBody -> Expected : %v, Output : %v", ErrBodyReadTimeout, err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("middleware.TimeoutBody -> Expected the read to be interrupted at the deadline")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("middleware.TimeoutBody -> Expected the read to be interrupted at the deadline, Output : %s", elapsed)
+	}
+
+	res, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("middleware.TimeoutBody -> Expected : %d, Output : %d", http.StatusGatewayTimeout, res.StatusCode)
+	}
+
+	// Fast bodies are read as usual
+	r.POST("/echo", func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		w.Write(body)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/echo", strings.NewReader("jett")))
+
+	if w.Code != http.StatusOK || w.Body.String() != "jett" {
+		t.Fatalf("middleware.TimeoutBody -> Expected : 200 jett, Output : %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareTimeoutFallback(t *testing.T) {
+	r := jett.New()
+
+	r.Use(TimeoutFallback(50*time.Millisecond, func(w http.ResponseWriter, req *http.Request) {
+		jett.Text(w, "stale", http.StatusOK)
+	}))
+
+	r.GET("/slow", func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-time.After(time.Second):
+		}
+		jett.Text(w, "fresh", http.StatusOK)
+	})
+
+	r.GET("/fast", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Jett", "fast")
+		jett.Text(w, "fresh", http.StatusCreated)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/slow", http.StatusOK, "stale"},
+		{"/fast", http.StatusCreated, "fresh"},
+	}
+
+	for _, test := range tests {
+		res, err := http.Get(ts.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.StatusCode != test.status || string(body) != test.body {
+			t.Fatalf("middleware.TimeoutFallback -> %s Expected : %d %s, Output : %d %s", test.path, test.status, test.body, res.StatusCode, body)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/saurabh0719/jett"
+)
+
+// Tx is a database transaction, eg. *sql.Tx
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+var txKey = jett.NewContextKey[Tx]("transaction")
+
+// Transaction is a middleware that runs every request in its own database transaction.
+// - begin -> starts the transaction, eg. func(ctx context.Context) (Tx, error) { return db.BeginTx(ctx, nil) }
+// - commitOn -> reports whether the response status should commit the transaction (nil commits on 2xx & 3xx)
+//
+// The transaction is made available to handlers with GetTx. It is rolled back when commitOn returns false
+// or the handler panics - the panic is then passed on, so use it after Recoverer -
+//
+//	r.Use(middleware.Recoverer)
+//	r.Use(middleware.Transaction(begin, nil))
+//
+// The response is buffered in memory and only written once the transaction is committed,
+// so clients never see a success response for a transaction that failed to commit.
+// Responds with 500 Internal Server Error if the transaction can't be started or committed.
+func Transaction(begin func(ctx context.Context) (Tx, error), commitOn func(status int) bool) func(next http.Handler) http.Handler {
+	if commitOn == nil {
+		commitOn = func(status int) bool {
+			return status < http.StatusBadRequest
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			tx, err := begin(req.Context())
+			if err != nil {
+				log.Printf("Transaction : %v", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			defer func() {
+				if err := recover(); err != nil {
+					tx.Rollback()
+					panic(err)
+				}
+			}()
+
+			bw := &bufferedWriter{ResponseWriter: w}
+			next.ServeHTTP(bw, req.WithContext(txKey.Set(req.Context(), tx)))
+
+			// Nothing or only the body was written, status defaults to 200
+			if bw.status == 0 {
+				bw.status = http.StatusOK
+			}
+
+			if commitOn(bw.status) {
+				if err := tx.Commit(); err != nil {
+					log.Printf("Transaction commit : %v", err)
+					w.Header().Del("Content-Length")
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					return
+				}
+			} else {
+				tx.Rollback()
+			}
+
+			w.WriteHeader(bw.status)
+			bw.buf.WriteTo(w)
+		})
+	}
+}
+
+// GetTx returns the transaction started by the Transaction middleware, or nil if there is none
+func GetTx(ctx context.Context) Tx {
+	tx, _ := txKey.Get(ctx)
+	return tx
+}
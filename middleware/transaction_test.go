@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+type testTx struct {
+	mu        sync.Mutex
+	result    string
+	commitErr error
+}
+
+func (tx *testTx) Commit() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.result = "commit"
+	return tx.commitErr
+}
+
+func (tx *testTx) Rollback() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.result = "rollback"
+	return nil
+}
+
+func TestMiddlewareTransaction(t *testing.T) {
+	// Silence Recoverer
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	tx := &testTx{}
+
+	r := jett.New()
+
+	r.Use(Recoverer)
+	r.Use(Transaction(func(ctx context.Context) (Tx, error) {
+		tx.result = ""
+		return tx, nil
+	}, nil))
+
+	r.GET("/ok", func(w http.ResponseWriter, req *http.Request) {
+		if GetTx(req.Context()) != tx {
+			t.Errorf("middleware.Transaction -> Expected the handler to get the transaction")
+		}
+		jett.Text(w, "ok", http.StatusOK)
+	})
+
+	r.GET("/error", func(w http.ResponseWriter, req *http.Request) {
+		jett.Text(w, "error", http.StatusInternalServerError)
+	})
+
+	r.GET("/panic", func(w http.ResponseWriter, req *http.Request) {
+		panic("oops")
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path   string
+		status int
+		result string
+	}{
+		{"/ok", http.StatusOK, "commit"},
+		{"/error", http.StatusInternalServerError, "rollback"},
+		{"/panic", http.StatusInternalServerError, "rollback"},
+	}
+
+	for _, test := range tests {
+		res, err := http.Get(ts.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.Transaction %s status -> Expected : %d, Output : %d", test.path, test.status, res.StatusCode)
+		}
+
+		tx.mu.Lock()
+		result := tx.result
+		tx.mu.Unlock()
+
+		if result != test.result {
+			t.Fatalf("middleware.Transaction %s -> Expected : %s, Output : %s", test.path, test.result, result)
+		}
+	}
+}
+
+func TestMiddlewareTransactionCommitError(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	tx := &testTx{commitErr: errors.New("connection lost")}
+
+	r := jett.New()
+
+	r.Use(Transaction(func(ctx context.Context) (Tx, error) {
+		return tx, nil
+	}, nil))
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+		jett.JSON(w, "created", http.StatusCreated)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	// The handler's response is discarded, the commit failed
+	expected := http.StatusText(http.StatusInternalServerError) + "\n"
+	if res.StatusCode != http.StatusInternalServerError || string(body) != expected {
+		t.Fatalf("middleware.Transaction commit error -> Expected : %d %q, Output : %d %q", http.StatusInternalServerError, expected, res.StatusCode, body)
+	}
+}
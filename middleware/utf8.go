@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// RequireUTF8 is a middleware that checks that JSON and text request bodies
+// are valid UTF-8, responding with 400 Bad Request when invalid byte sequences are found.
+// Prevents corrupt data from reaching handlers (and the database).
+//
+// The body is buffered in memory to be validated, so it's best used along
+// with a limit on the body size. Other content types pass through untouched.
+func RequireUTF8(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Body == nil || !isTextMediaType(req.Header.Get("Content-Type")) {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil || !utf8.Valid(body) {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Restore the body for downstream handlers
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// JSON (application/json, application/*+json) and text/* media types
+func isTextMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" ||
+		strings.HasSuffix(mediaType, "+json") ||
+		strings.HasPrefix(mediaType, "text/")
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareRequireUTF8(t *testing.T) {
+	r := jett.New()
+
+	r.Use(RequireUTF8)
+
+	r.POST("/", func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		w.Write(body)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		contentType string
+		body        []byte
+		status      int
+	}{
+		{"application/json", []byte(`{"name":"jétt"}`), http.StatusOK},
+		{"text/plain; charset=utf-8", []byte("jett \xff\xfe"), http.StatusBadRequest},
+		{"application/json", []byte("{\"name\":\"j\xc3\x28tt\"}"), http.StatusBadRequest},
+		{"application/octet-stream", []byte("\xff\xfe"), http.StatusOK},
+	}
+
+	for _, test := range tests {
+		res, err := http.Post(ts.URL, test.contentType, bytes.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("middleware.RequireUTF8 -> %q Expected : %d, Output : %d", test.body, test.status, res.StatusCode)
+		}
+
+		if test.status == http.StatusOK && !bytes.Equal(body, test.body) {
+			t.Fatalf("middleware.RequireUTF8 -> Expected the handler to read : %q, Output : %q", test.body, body)
+		}
+	}
+}
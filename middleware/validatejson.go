@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ValidateJSONResponse is a development middleware that catches handlers writing malformed JSON.
+// Responses with a JSON Content-Type (application/json, application/*+json) are copied as they're
+// written and checked with json.Valid once the handler returns, logging a warning when invalid.
+// The response itself is written through untouched.
+//
+// Every JSON response is held in memory, don't use it in production. Set enabled from
+// the environment so the middleware can stay registered, it's a no-op when disabled -
+//
+//	r.Use(middleware.ValidateJSONResponse(os.Getenv("APP_ENV") == "development"))
+func ValidateJSONResponse(enabled bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			jw := &jsonCopyWriter{ResponseWriter: w}
+
+			next.ServeHTTP(jw, req)
+
+			if jw.isJSON && jw.buf.Len() > 0 && !json.Valid(jw.buf.Bytes()) {
+				log.Printf("WARNING: invalid JSON response - %s %s (Content-Type: %s): %.200q",
+					req.Method, req.URL.Path, w.Header().Get("Content-Type"), jw.buf.Bytes())
+			}
+		})
+	}
+}
+
+// Copies the body of JSON responses as it's written through
+type jsonCopyWriter struct {
+	http.ResponseWriter
+	buf         bytes.Buffer
+	isJSON      bool
+	wroteHeader bool
+}
+
+func (jw *jsonCopyWriter) WriteHeader(code int) {
+	if !jw.wroteHeader {
+		jw.wroteHeader = true
+
+		mediaType, _, _ := mime.ParseMediaType(jw.Header().Get("Content-Type"))
+		jw.isJSON = mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+	}
+	jw.ResponseWriter.WriteHeader(code)
+}
+
+func (jw *jsonCopyWriter) Write(buf []byte) (int, error) {
+	if !jw.wroteHeader {
+		jw.WriteHeader(http.StatusOK)
+	}
+	if jw.isJSON {
+		jw.buf.Write(buf)
+	}
+	return jw.ResponseWriter.Write(buf)
+}
+
+// Implement http.Flusher interface so streaming handlers keep working
+func (jw *jsonCopyWriter) Flush() {
+	if !jw.wroteHeader {
+		jw.WriteHeader(http.StatusOK)
+	}
+	if f, ok := jw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
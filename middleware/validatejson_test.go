@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/saurabh0719/jett"
+)
+
+func TestMiddlewareValidateJSONResponse(t *testing.T) {
+	r := jett.New()
+
+	r.Use(ValidateJSONResponse(true))
+
+	r.GET("/valid", func(w http.ResponseWriter, req *http.Request) {
+		jett.JSON(w, map[string]string{"name": "jett"}, http.StatusOK)
+	})
+	r.GET("/invalid", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"name": "jett",}`)
+	})
+	r.GET("/text", func(w http.ResponseWriter, req *http.Request) {
+		jett.Text(w, `{"name": `, http.StatusOK)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	tests := []struct {
+		path    string
+		warning bool
+	}{
+		{"/valid", false},
+		{"/invalid", true},
+		{"/text", false},
+	}
+
+	for _, test := range tests {
+		buf.Reset()
+
+		res, err := http.Get(ts.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if warned := strings.Contains(buf.String(), "WARNING: invalid JSON response - GET "+test.path); warned != test.warning {
+			t.Fatalf("middleware.ValidateJSONResponse %s -> Expected warning : %v, Output : %q", test.path, test.warning, buf.String())
+		}
+	}
+}
+
+func TestMiddlewareValidateJSONResponseDisabled(t *testing.T) {
+	r := jett.New()
+
+	r.Use(ValidateJSONResponse(false))
+
+	r.GET("/invalid", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"name": "jett",}`)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	res, err := http.Get(ts.URL + "/invalid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if buf.Len() != 0 {
+		t.Fatalf("middleware.ValidateJSONResponse disabled -> Expected no warning, Output : %q", buf.String())
+	}
+}
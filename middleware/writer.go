@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// Wraps http.ResponseWriter to run a hook right before the headers are written,
+// for middleware that need to set headers after the handler had a chance to set its own
+type headerHookWriter struct {
+	http.ResponseWriter
+	beforeHeader func()
+	wroteHeader  bool
+}
+
+func (hw *headerHookWriter) WriteHeader(code int) {
+	if !hw.wroteHeader {
+		hw.wroteHeader = true
+		hw.beforeHeader()
+	}
+	hw.ResponseWriter.WriteHeader(code)
+}
+
+func (hw *headerHookWriter) Write(buf []byte) (int, error) {
+	if !hw.wroteHeader {
+		hw.WriteHeader(http.StatusOK)
+	}
+	return hw.ResponseWriter.Write(buf)
+}
+
+// Implement http.Flusher interface so streaming handlers keep working
+func (hw *headerHookWriter) Flush() {
+	if !hw.wroteHeader {
+		hw.WriteHeader(http.StatusOK)
+	}
+	if f, ok := hw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Implement http.Pusher interface so HTTP/2 server push keeps working
+func (hw *headerHookWriter) Push(target string, opts *http.PushOptions) error {
+	if pusher, ok := hw.ResponseWriter.(http.Pusher); ok {
+		return pusher.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Returns the wrapped http.ResponseWriter, for http.ResponseController
+func (hw *headerHookWriter) Unwrap() http.ResponseWriter {
+	return hw.ResponseWriter
+}
+
+// Buffers the response so it can be rewritten once the handler is done
+type bufferedWriter struct {
+	http.ResponseWriter
+	buf    bytes.Buffer
+	status int
+}
+
+func (bw *bufferedWriter) WriteHeader(code int) {
+	if bw.status == 0 {
+		bw.status = code
+	}
+}
+
+func (bw *bufferedWriter) Write(buf []byte) (int, error) {
+	return bw.buf.Write(buf)
+}
@@ -0,0 +1,130 @@
+package jett
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+/* -------------------------- RANGE REQUESTS ------------------------- */
+
+// Errors returned by ParseRange
+var (
+	// The Range header is malformed, it should be ignored and the full content served
+	ErrInvalidRange = errors.New("jett: invalid range")
+
+	// None of the ranges overlap the content, respond with 416 Range Not Satisfiable
+	ErrRangeNotSatisfiable = errors.New("jett: range not satisfiable")
+)
+
+// HTTPRange is a single byte range of content, as requested with the Range header
+type HTTPRange struct {
+	Start  int64
+	Length int64
+}
+
+// ContentRange returns the value of the Content-Range header for the range,
+// eg. "bytes 0-499/1234"
+func (r HTTPRange) ContentRange(size int64) string {
+	return "bytes " + strconv.FormatInt(r.Start, 10) + "-" + strconv.FormatInt(r.Start+r.Length-1, 10) + "/" + strconv.FormatInt(size, 10)
+}
+
+// Parses the Range header of the request (RFC 7233) into ranges validated
+// against the size of the content, for handlers serving partial content
+// of generated (non file based) data.
+//
+// Returns nil ranges when no Range header is present.
+// Returns ErrRangeNotSatisfiable when none of the ranges overlap the content -
+// respond with 416 and a Content-Range of "bytes */<size>".
+//
+//	ranges, err := jett.ParseRange(req, size)
+//	if err == jett.ErrRangeNotSatisfiable {
+//		w.Header().Set("Content-Range", "bytes */"+strconv.FormatInt(size, 10))
+//		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+//		return
+//	}
+//
+// Adapted from net/http's parseRange.
+func ParseRange(req *http.Request, size int64) ([]HTTPRange, error) {
+	header := req.Header.Get("Range")
+	if header == "" {
+		return nil, nil
+	}
+
+	const unit = "bytes="
+	if !strings.HasPrefix(header, unit) {
+		return nil, ErrInvalidRange
+	}
+
+	var ranges []HTTPRange
+	noOverlap := false
+
+	for _, spec := range strings.Split(header[len(unit):], ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		i := strings.IndexByte(spec, '-')
+		if i < 0 {
+			return nil, ErrInvalidRange
+		}
+		start, end := strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:])
+
+		var r HTTPRange
+		if start == "" {
+			// Suffix range - the last n bytes
+			if end == "" || end[0] == '-' {
+				return nil, ErrInvalidRange
+			}
+			n, err := strconv.ParseInt(end, 10, 64)
+			if err != nil || n < 0 {
+				return nil, ErrInvalidRange
+			}
+			if n == 0 {
+				noOverlap = true
+				continue
+			}
+			if n > size {
+				n = size
+			}
+			r.Start = size - n
+			r.Length = n
+		} else {
+			s, err := strconv.ParseInt(start, 10, 64)
+			if err != nil || s < 0 {
+				return nil, ErrInvalidRange
+			}
+			if s >= size {
+				// The range begins after the content ends
+				noOverlap = true
+				continue
+			}
+			r.Start = s
+			if end == "" {
+				// Open ended range - from start to the end of the content
+				r.Length = size - s
+			} else {
+				e, err := strconv.ParseInt(end, 10, 64)
+				if err != nil || s > e {
+					return nil, ErrInvalidRange
+				}
+				if e >= size {
+					e = size - 1
+				}
+				r.Length = e - s + 1
+			}
+		}
+		ranges = append(ranges, r)
+	}
+
+	if noOverlap && len(ranges) == 0 {
+		return nil, ErrRangeNotSatisfiable
+	}
+	if len(ranges) == 0 {
+		return nil, ErrInvalidRange
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,41 @@
+package jett
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected []HTTPRange
+		err      error
+	}{
+		{"", nil, nil},
+		{"bytes=0-499", []HTTPRange{{0, 500}}, nil},
+		{"bytes=500-", []HTTPRange{{500, 500}}, nil},
+		{"bytes=-200", []HTTPRange{{800, 200}}, nil},
+		{"bytes=0-0, 990-2000", []HTTPRange{{0, 1}, {990, 10}}, nil},
+		{"bytes=1000-1200", nil, ErrRangeNotSatisfiable},
+		{"bytes=500-100", nil, ErrInvalidRange},
+		{"items=0-5", nil, ErrInvalidRange},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		if test.header != "" {
+			req.Header.Set("Range", test.header)
+		}
+
+		ranges, err := ParseRange(req, 1000)
+		if err != test.err || !reflect.DeepEqual(ranges, test.expected) {
+			t.Fatalf("ParseRange -> %q Expected : %v %v, Output : %v %v", test.header, test.expected, test.err, ranges, err)
+		}
+	}
+
+	expected := "bytes 800-999/1000"
+	if output := (HTTPRange{800, 200}).ContentRange(1000); output != expected {
+		t.Fatalf("HTTPRange.ContentRange -> Expected : %s, Output : %s", expected, output)
+	}
+}
@@ -0,0 +1,44 @@
+package jett
+
+import (
+	"context"
+	"time"
+)
+
+/* -------------------------- RETRIES ------------------------- */
+
+// RetryDo calls fn until it succeeds, up to attempts times, eg. for idempotent calls to a flaky upstream -
+//
+//	err := jett.RetryDo(req.Context(), 3, 100*time.Millisecond, func() error {
+//		return fetchInventory(req.Context())
+//	})
+//
+// The wait between attempts starts at backoff and doubles after each one (100ms, 200ms, 400ms ...).
+// Returns the last error of fn once the attempts are exhausted, or ctx.Err() as soon as ctx is done
+// (eg. the client went away). Only retry operations that are safe to repeat.
+func RetryDo(ctx context.Context, attempts int, backoff time.Duration, fn func() error) error {
+	var err error
+
+	for attempt := 0; attempt < attempts || attempt == 0; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(backoff << (attempt - 1))
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		if err = fn(); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,62 @@
+package jett
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryDo(t *testing.T) {
+	errUpstream := errors.New("upstream unavailable")
+
+	// Succeeds on the third attempt
+	calls := 0
+	start := time.Now()
+	err := RetryDo(context.Background(), 5, 10*time.Millisecond, func() error {
+		calls++
+		if calls < 3 {
+			return errUpstream
+		}
+		return nil
+	})
+
+	if err != nil || calls != 3 {
+		t.Fatalf("RetryDo success -> Expected : <nil> after 3 calls, Output : %v after %d calls", err, calls)
+	}
+
+	// 10ms + 20ms of backoff
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("RetryDo backoff -> Expected : at least 30ms, Output : %s", elapsed)
+	}
+
+	// Exhausts the attempts
+	calls = 0
+	err = RetryDo(context.Background(), 3, time.Millisecond, func() error {
+		calls++
+		return errUpstream
+	})
+
+	if err != errUpstream || calls != 3 {
+		t.Fatalf("RetryDo exhausted -> Expected : %v after 3 calls, Output : %v after %d calls", errUpstream, err, calls)
+	}
+
+	// Context cancelled during the backoff
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	calls = 0
+	start = time.Now()
+	err = RetryDo(ctx, 5, time.Second, func() error {
+		calls++
+		return errUpstream
+	})
+
+	if err != context.DeadlineExceeded || calls != 1 {
+		t.Fatalf("RetryDo cancelled -> Expected : %v after 1 call, Output : %v after %d calls", context.DeadlineExceeded, err, calls)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("RetryDo cancelled -> Expected to abort early, Output : %s", elapsed)
+	}
+}
@@ -0,0 +1,230 @@
+package jett
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+/* -------------------------- ROUTE REGISTRY ------------------------- */
+
+// Route is returned when a handler is registered.
+// Documentation metadata can be attached to it inline for tooling (docs, OpenAPI etc.)
+//
+//	r.GET("/users/:id", GetUser).
+//		Summary("Get a user").
+//		Tags("users")
+type Route struct {
+	method string
+	path   string
+	meta   RouteMetadata
+
+	// handler -> the route's handler wrapped with its route-specific middleware
+	handler http.Handler
+
+	// routeMiddleware -> number of route-specific middleware wrapping handler
+	routeMiddleware int
+
+	// router -> the router the route was registered on, whose middleware stack wraps handler
+	router *Router
+
+	// cached -> *composedChain, handler wrapped with the router's middleware stack
+	cached atomic.Value
+
+	// contentType -> default Content-Type of the route's responses
+	contentType string
+
+	// enabled -> feature flag checked on every request, the route responds
+	// with notFound while it's off. nil if the route is always enabled
+	enabled  func() bool
+	notFound http.HandlerFunc
+}
+
+// RouteMetadata holds the documentation attached to a Route
+type RouteMetadata struct {
+	Summary     string
+	Description string
+	Tags        []string
+}
+
+// Method returns the http method of the route
+func (rt *Route) Method() string {
+	return rt.method
+}
+
+// Path returns the full path of the route from root
+func (rt *Route) Path() string {
+	return rt.path
+}
+
+// Metadata returns the documentation attached to the route
+func (rt *Route) Metadata() RouteMetadata {
+	return rt.meta
+}
+
+// MiddlewareNames returns the names of the router middleware wrapping the route
+// (see Router.UseNamed), "" for middleware added with Use.
+func (rt *Route) MiddlewareNames() []string {
+	return rt.router.MiddlewareNames()
+}
+
+// Summary sets a short summary of what the route does
+func (rt *Route) Summary(summary string) *Route {
+	rt.meta.Summary = summary
+	return rt
+}
+
+// Description sets a longer description of the route
+func (rt *Route) Description(description string) *Route {
+	rt.meta.Description = description
+	return rt
+}
+
+// Tags appends tags used to group the route
+func (rt *Route) Tags(tags ...string) *Route {
+	rt.meta.Tags = append(rt.meta.Tags, tags...)
+	return rt
+}
+
+// Produces sets the default Content-Type of the route's responses,
+// eg. for an endpoint that always returns XML.
+// Handlers (and renderers) can still override it by setting the header.
+func (rt *Route) Produces(contentType string) *Route {
+	rt.contentType = contentType
+	return rt
+}
+
+// routerKey -> the router a request was routed by, so helpers can respond with its NotFound handler
+var routerKey = NewContextKey[*Router]("router")
+
+// Serves the request with the route's handler.
+// Registered with httprouter so route settings can be changed after registration.
+func (rt *Route) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if rt.enabled != nil && !rt.enabled() {
+		rt.notFound(w, req)
+		return
+	}
+	if rt.contentType != "" {
+		w.Header().Set("Content-Type", rt.contentType)
+	}
+	rt.chain().ServeHTTP(w, req.WithContext(routerKey.Set(req.Context(), rt.router)))
+}
+
+// A route's handler composed with a version of the router's middleware stack
+type composedChain struct {
+	version uint64
+	handler http.Handler
+}
+
+// Returns the route's handler wrapped with the router's middleware stack.
+// The chain is cached and only composed again once the stack has changed (Router.Use).
+func (rt *Route) chain() http.Handler {
+	version := atomic.LoadUint64(&rt.router.middlewareVersion)
+	if c, ok := rt.cached.Load().(*composedChain); ok && c.version == version {
+		return c.handler
+	}
+
+	rt.router.mu.RLock()
+	c := &composedChain{
+		version: rt.router.middlewareVersion,
+		handler: rt.handler,
+	}
+	for i := len(rt.router.middleware) - 1; i >= 0; i-- {
+		c.handler = rt.router.middleware[i](c.handler)
+	}
+	rt.router.mu.RUnlock()
+
+	rt.cached.Store(c)
+	return c.handler
+}
+
+// RouterStats summarizes the route table, eg. to monitor its growth in large apps
+type RouterStats struct {
+	// Total -> number of registered routes
+	Total int
+
+	// ByMethod -> number of registered routes per http method
+	ByMethod map[string]int
+}
+
+// Stats returns the number of routes registered on the router and all its subrouters
+// (the route table is shared), in total and per method.
+func (r *Router) Stats() RouterStats {
+	stats := RouterStats{
+		ByMethod: make(map[string]int),
+	}
+
+	for _, rt := range r.registry.routes {
+		stats.Total++
+		stats.ByMethod[rt.method]++
+	}
+
+	return stats
+}
+
+// RouteInfo describes a registered route, see Router.Routes
+type RouteInfo struct {
+	// Method -> http method of the route
+	Method string
+
+	// Path -> full path of the route from root, including subrouter prefixes
+	Path string
+
+	// Middleware -> number of middleware applied to the route (router stack + route-specific)
+	Middleware int
+
+	// MiddlewareNames -> names of the router middleware wrapping the route (see Router.UseNamed),
+	// "" for middleware added with Use. Route-specific middleware isn't named.
+	MiddlewareNames []string
+
+	// Documentation attached to the route (Summary, Description, Tags), eg. for an OpenAPI generator
+	RouteMetadata
+}
+
+// Routes returns every route registered on the router and all its subrouters
+// (the route table is shared) in order of registration along with their metadata,
+// eg. to generate docs or debug routing.
+func (r *Router) Routes() []RouteInfo {
+	routes := make([]RouteInfo, 0, len(r.registry.routes))
+
+	for _, rt := range r.registry.routes {
+		names := rt.router.MiddlewareNames()
+
+		meta := rt.meta
+		meta.Tags = append([]string(nil), rt.meta.Tags...)
+
+		routes = append(routes, RouteInfo{
+			Method:          rt.method,
+			Path:            rt.path,
+			Middleware:      len(names) + rt.routeMiddleware,
+			MiddlewareNames: names,
+			RouteMetadata:   meta,
+		})
+	}
+
+	return routes
+}
+
+// Keeps track of every route registered on a router and its subrouters
+type routeRegistry struct {
+	routes []*Route
+}
+
+func (rr *routeRegistry) add(method, path string, handler http.Handler) *Route {
+	rt := &Route{
+		method:  method,
+		path:    path,
+		handler: handler,
+	}
+	rr.routes = append(rr.routes, rt)
+	return rt
+}
+
+// Returns the route registered for the method and full path, nil if not found
+func (rr *routeRegistry) get(method, path string) *Route {
+	for _, rt := range rr.routes {
+		if rt.method == method && rt.path == path {
+			return rt
+		}
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package jett
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRouteMetadata(t *testing.T) {
+	r := New()
+
+	sr := r.Subrouter("/users")
+	sr.GET("/:id", Home).
+		Summary("Get a user").
+		Description("Returns a single user by id").
+		Tags("users", "public")
+
+	routes := r.Routes()
+	if len(routes) != 1 || routes[0].Method != "GET" || routes[0].Path != "/users/:id" {
+		t.Fatalf("Router.Routes -> Expected : GET /users/:id, Output : %+v", routes)
+	}
+
+	expected := RouteMetadata{
+		Summary:     "Get a user",
+		Description: "Returns a single user by id",
+		Tags:        []string{"users", "public"},
+	}
+
+	if !reflect.DeepEqual(routes[0].RouteMetadata, expected) {
+		t.Fatalf("Router.Routes metadata -> Expected : %+v, Output : %+v", expected, routes[0].RouteMetadata)
+	}
+
+	if routes[0].Summary != expected.Summary {
+		t.Fatalf("RouteInfo.Summary -> Expected : %s, Output : %s", expected.Summary, routes[0].Summary)
+	}
+}
+
+func TestRouteProduces(t *testing.T) {
+	r := New()
+
+	r.GET("/xml", func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, "<jett></jett>")
+	}).Produces("application/xml")
+
+	r.GET("/override", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		io.WriteString(w, "a,b")
+	}).Produces("application/xml")
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path        string
+		contentType string
+	}{
+		{"/xml", "application/xml"},
+		{"/override", "text/csv"},
+	}
+
+	for _, test := range tests {
+		res, err := http.Get(ts.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if output := res.Header.Get("Content-Type"); output != test.contentType {
+			t.Fatalf("Route.Produces -> %s Expected : %s, Output : %s", test.path, test.contentType, output)
+		}
+	}
+}
+
+func TestRouteIf(t *testing.T) {
+	var enabled int32
+
+	r := New()
+
+	r.GETIf(func() bool { return atomic.LoadInt32(&enabled) == 1 }, "/beta", func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, "beta")
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		enabled int32
+		status  int
+	}{
+		{0, http.StatusNotFound},
+		{1, http.StatusOK},
+		{0, http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		atomic.StoreInt32(&enabled, test.enabled)
+
+		res, err := http.Get(ts.URL + "/beta")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != test.status {
+			t.Fatalf("GETIf -> flag: %d Expected : %d, Output : %d", test.enabled, test.status, res.StatusCode)
+		}
+	}
+}
+
+func TestRouterStats(t *testing.T) {
+	r := New()
+
+	r.GET("/", Home)
+	r.GET("/about", About)
+	r.POST("/about", About)
+
+	sr := r.Subrouter("/users")
+	sr.GET("/:id", Home)
+	sr.DELETE("/:id", Home)
+
+	expected := RouterStats{
+		Total: 5,
+		ByMethod: map[string]int{
+			"GET":    3,
+			"POST":   1,
+			"DELETE": 1,
+		},
+	}
+
+	if output := r.Stats(); !reflect.DeepEqual(output, expected) {
+		t.Fatalf("Router.Stats -> Expected : %+v, Output : %+v", expected, output)
+	}
+}
+
+func TestRouteChainCached(t *testing.T) {
+	r := New()
+
+	var composed int32
+	counter := func(next http.Handler) http.Handler {
+		atomic.AddInt32(&composed, 1)
+		return next
+	}
+
+	r.GET("/", Home)
+
+	// Use after registering the route still applies to it
+	r.Use(counter)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		res, err := http.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	if output := atomic.LoadInt32(&composed); output != 1 {
+		t.Fatalf("Route chain compositions -> Expected : %d, Output : %d", 1, output)
+	}
+
+	// Changing the middleware stack invalidates the cached chain
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("X-Jett", "true")
+			next.ServeHTTP(w, req)
+		})
+	})
+
+	res, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	header := res.Header.Get("X-Jett")
+
+	if output := atomic.LoadInt32(&composed); output != 2 {
+		t.Fatalf("Route chain compositions after Use -> Expected : %d, Output : %d", 2, output)
+	}
+
+	if header != "true" {
+		t.Fatalf("Route chain after Use -> Expected : %s, Output : %s", "true", header)
+	}
+}
+
+func TestUseNamed(t *testing.T) {
+	r := New()
+
+	header := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Add("X-Middleware", name)
+				next.ServeHTTP(w, req)
+			})
+		}
+	}
+
+	r.UseNamed("auth", header("auth"))
+	r.Use(header("unnamed"))
+	r.UseNamed("logger", header("logger"))
+
+	r.GET("/private", Home)
+
+	public := r.Subrouter("/public")
+	public.Without("auth")
+	publicRoute := public.GET("/", Home)
+
+	tests := []struct {
+		name     string
+		names    []string
+		expected []string
+	}{
+		{"Router.MiddlewareNames", r.MiddlewareNames(), []string{"auth", "", "logger"}},
+		{"Subrouter.MiddlewareNames", public.MiddlewareNames(), []string{"", "logger"}},
+		{"Route.MiddlewareNames", publicRoute.MiddlewareNames(), []string{"", "logger"}},
+	}
+
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.names, test.expected) {
+			t.Fatalf("%s -> Expected : %q, Output : %q", test.name, test.expected, test.names)
+		}
+	}
+
+	if len(public.Middleware()) != 2 {
+		t.Fatalf("Without -> Expected : %d middleware, Output : %d", 2, len(public.Middleware()))
+	}
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	for path, expected := range map[string][]string{
+		"/private": {"auth", "unnamed", "logger"},
+		"/public/": {"unnamed", "logger"},
+	} {
+		res, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if ran := res.Header.Values("X-Middleware"); !reflect.DeepEqual(ran, expected) {
+			t.Fatalf("UseNamed %s -> Expected : %v, Output : %v", path, expected, ran)
+		}
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	r := New()
+
+	passthrough := func(next http.Handler) http.Handler {
+		return next
+	}
+
+	r.UseNamed("logger", passthrough)
+	r.GET("/", Home)
+
+	api := r.Subrouter("/api")
+	api.Use(passthrough)
+	api.UseNamed("auth", passthrough)
+	api.POST("/users", Home, passthrough, passthrough)
+
+	expected := []RouteInfo{
+		{Method: "GET", Path: "/", Middleware: 1, MiddlewareNames: []string{"logger"}},
+		{Method: "POST", Path: "/api/users", Middleware: 5, MiddlewareNames: []string{"logger", "", "auth"}},
+	}
+
+	if routes := r.Routes(); !reflect.DeepEqual(routes, expected) {
+		t.Fatalf("Router.Routes -> Expected : %+v, Output : %+v", expected, routes)
+	}
+
+	if routes := api.Routes(); !reflect.DeepEqual(routes, expected) {
+		t.Fatalf("Subrouter.Routes -> Expected : %+v, Output : %+v", expected, routes)
+	}
+}
+
+func BenchmarkRouteChain(b *testing.B) {
+	r := New()
+
+	for i := 0; i < 5; i++ {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				next.ServeHTTP(w, req)
+			})
+		})
+	}
+
+	r.GET("/", func(w http.ResponseWriter, req *http.Request) {})
+
+	rt := r.registry.get("GET", "/")
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rt.serveHTTP(w, req)
+	}
+}
@@ -0,0 +1,44 @@
+package jett
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+/* -------------------------- HOT RELOADING ------------------------- */
+
+// SwappableHandler serves requests with a Router that can be replaced at runtime,
+// eg. to reload routes from config on SIGHUP without restarting the server -
+//
+//	sh := jett.NewSwappableHandler(buildRouter(cfg))
+//	server := &http.Server{Addr: ":8000", Handler: sh}
+//
+//	// on reload
+//	sh.Swap(buildRouter(newCfg))
+//
+// Requests already being served finish on the old router, new requests are routed by the new one.
+type SwappableHandler struct {
+	router atomic.Value
+}
+
+// NewSwappableHandler returns a SwappableHandler serving r
+func NewSwappableHandler(r *Router) *SwappableHandler {
+	sh := &SwappableHandler{}
+	sh.router.Store(r)
+	return sh
+}
+
+// Swap atomically replaces the router serving new requests
+func (sh *SwappableHandler) Swap(r *Router) {
+	sh.router.Store(r)
+}
+
+// Router returns the router currently serving requests
+func (sh *SwappableHandler) Router() *Router {
+	return sh.router.Load().(*Router)
+}
+
+// Implement http.Handler interface
+func (sh *SwappableHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	sh.Router().ServeHTTP(w, req)
+}
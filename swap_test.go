@@ -0,0 +1,84 @@
+package jett
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSwappableHandler(t *testing.T) {
+	v1 := New()
+	v1.GET("/version", func(w http.ResponseWriter, req *http.Request) {
+		Text(w, "v1", http.StatusOK)
+	})
+
+	// Blocks until released to stay in flight across the swap
+	release := make(chan struct{})
+	started := make(chan struct{})
+	v1.GET("/slow", func(w http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-release
+		Text(w, "v1 slow", http.StatusOK)
+	})
+
+	v2 := New()
+	v2.GET("/version", func(w http.ResponseWriter, req *http.Request) {
+		Text(w, "v2", http.StatusOK)
+	})
+	v2.GET("/new", func(w http.ResponseWriter, req *http.Request) {
+		Text(w, "new route", http.StatusOK)
+	})
+
+	sh := NewSwappableHandler(v1)
+
+	ts := httptest.NewServer(sh)
+	defer ts.Close()
+
+	get := func(path string) (int, string) {
+		res, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Error(err)
+			return 0, ""
+		}
+		defer res.Body.Close()
+
+		body, _ := ioutil.ReadAll(res.Body)
+		return res.StatusCode, string(body)
+	}
+
+	slow := make(chan string)
+	go func() {
+		_, body := get("/slow")
+		slow <- body
+	}()
+	<-started
+
+	sh.Swap(v2)
+
+	if sh.Router() != v2 {
+		t.Fatalf("SwappableHandler.Router -> Expected the swapped router")
+	}
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/version", http.StatusOK, "v2"},
+		{"/new", http.StatusOK, "new route"},
+		{"/slow", http.StatusNotFound, "404 page not found\n"},
+	}
+
+	for _, test := range tests {
+		if status, body := get(test.path); status != test.status || body != test.body {
+			t.Fatalf("SwappableHandler %s -> Expected : %d %q, Output : %d %q", test.path, test.status, test.body, status, body)
+		}
+	}
+
+	// The in-flight request finishes on the old router
+	close(release)
+	if body := <-slow; body != "v1 slow" {
+		t.Fatalf("SwappableHandler in-flight -> Expected : %q, Output : %q", "v1 slow", body)
+	}
+}
@@ -0,0 +1,127 @@
+package jett
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/http"
+	"path/filepath"
+	"sync"
+)
+
+/* -------------------------- CACHED HTML TEMPLATES ------------------------- */
+
+// TemplateRenderer renders a set of html files that are parsed once, up front,
+// instead of on every request like HTML -
+//
+//	tr, err := jett.NewTemplateRenderer("layout.html", "index.html")
+//	tr.DevMode = os.Getenv("ENV") == "dev"
+//
+//	r.GET("/", func(w http.ResponseWriter, req *http.Request) {
+//		tr.HTML(w, data)
+//	})
+type TemplateRenderer struct {
+	// DevMode -> re-parse the files on every render so template changes are
+	// picked up without a restart. Set it before serving requests.
+	DevMode bool
+
+	files []string
+	funcs template.FuncMap
+
+	mu   sync.RWMutex
+	tmpl *template.Template
+}
+
+// NewTemplateRenderer parses the html files (in order of parent -> children)
+// and returns a TemplateRenderer that caches them.
+func NewTemplateRenderer(htmlFiles ...string) (*TemplateRenderer, error) {
+	return NewTemplateRendererFuncs(nil, htmlFiles...)
+}
+
+// NewTemplateRendererFuncs is NewTemplateRenderer with custom functions
+// that the templates can call, eg. {{formatDate .CreatedAt}} -
+//
+//	tr, err := jett.NewTemplateRendererFuncs(template.FuncMap{
+//		"formatDate": func(t time.Time) string { return t.Format("2 Jan 2006") },
+//	}, "layout.html", "index.html")
+func NewTemplateRendererFuncs(funcs template.FuncMap, htmlFiles ...string) (*TemplateRenderer, error) {
+	if len(htmlFiles) == 0 {
+		return nil, errors.New("jett: no template files")
+	}
+
+	tr := &TemplateRenderer{
+		files: htmlFiles,
+		funcs: funcs,
+	}
+
+	if err := tr.parse(); err != nil {
+		return nil, err
+	}
+
+	return tr, nil
+}
+
+// Parses the files and replaces the cached templates
+func (tr *TemplateRenderer) parse() error {
+	// Functions must be added before parsing, the root template is named after the first file
+	// like with template.ParseFiles
+	t, err := template.New(filepath.Base(tr.files[0])).Funcs(tr.funcs).ParseFiles(tr.files...)
+	if err != nil {
+		return err
+	}
+
+	tr.mu.Lock()
+	tr.tmpl = t
+	tr.mu.Unlock()
+
+	return nil
+}
+
+// Returns the templates to render, re-parsing them first in DevMode
+func (tr *TemplateRenderer) templates() (*template.Template, error) {
+	if tr.DevMode {
+		if err := tr.parse(); err != nil {
+			return nil, err
+		}
+	}
+
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	return tr.tmpl, nil
+}
+
+// HTML renders the cached templates, works like the HTML renderer.
+// Sets the Content-Type header to text/html.
+func (tr *TemplateRenderer) HTML(w http.ResponseWriter, data interface{}) {
+	tr.render(w, "", data)
+}
+
+// Render executes the cached template with the given name, eg. a {{define "content"}} block
+// or the base name of one of the files. Sets the Content-Type header to text/html.
+func (tr *TemplateRenderer) Render(w http.ResponseWriter, name string, data interface{}) {
+	tr.render(w, name, data)
+}
+
+// Executes the named template, or the root template if name is empty
+func (tr *TemplateRenderer) render(w http.ResponseWriter, name string, data interface{}) {
+	t, err := tr.templates()
+	if err != nil {
+		internalError(w, nil, err)
+		return
+	}
+
+	htmlBuffer := new(bytes.Buffer)
+	if name == "" {
+		err = t.Execute(htmlBuffer, data)
+	} else {
+		err = t.ExecuteTemplate(htmlBuffer, name, data)
+	}
+	if err != nil {
+		internalError(w, nil, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	htmlBuffer.WriteTo(w)
+}
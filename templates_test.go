@@ -0,0 +1,166 @@
+package jett
+
+import (
+	"html/template"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTemplateRendererDevMode(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "index.html")
+	if err := os.WriteFile(file, []byte("<h1>{{.}}</h1>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tr, err := NewTemplateRenderer(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	render := func() string {
+		w := httptest.NewRecorder()
+		tr.HTML(w, "Jett")
+		body, _ := ioutil.ReadAll(w.Body)
+		return string(body)
+	}
+
+	if output := render(); output != "<h1>Jett</h1>" {
+		t.Fatalf("TemplateRenderer.HTML -> Expected : %s, Output : %s", "<h1>Jett</h1>", output)
+	}
+
+	// Edit the template while "running"
+	if err := os.WriteFile(file, []byte("<h2>{{.}}</h2>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if output := render(); output != "<h1>Jett</h1>" {
+		t.Fatalf("TemplateRenderer.HTML cached -> Expected : %s, Output : %s", "<h1>Jett</h1>", output)
+	}
+
+	tr.DevMode = true
+
+	if output := render(); output != "<h2>Jett</h2>" {
+		t.Fatalf("TemplateRenderer.HTML DevMode -> Expected : %s, Output : %s", "<h2>Jett</h2>", output)
+	}
+}
+
+func TestTemplateRendererRender(t *testing.T) {
+	dir := t.TempDir()
+
+	layout := filepath.Join(dir, "layout.html")
+	page := filepath.Join(dir, "page.html")
+
+	os.WriteFile(layout, []byte(`<main>{{template "content" .}}</main>`), 0644)
+	os.WriteFile(page, []byte(`{{define "content"}}<p>{{.}}</p>{{end}}`), 0644)
+
+	tr, err := NewTemplateRenderer(layout, page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		expected string
+	}{
+		{"layout.html", 200, "<main><p>Jett</p></main>"},
+		{"content", 200, "<p>Jett</p>"},
+		{"missing", 500, ""},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		tr.Render(w, test.name, "Jett")
+
+		if w.Code != test.status {
+			t.Fatalf("TemplateRenderer.Render %s -> Expected : %d, Output : %d", test.name, test.status, w.Code)
+		}
+
+		if test.status == 200 && w.Body.String() != test.expected {
+			t.Fatalf("TemplateRenderer.Render %s -> Expected : %s, Output : %s", test.name, test.expected, w.Body.String())
+		}
+	}
+}
+
+func TestTemplateRendererFuncs(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "index.html")
+	os.WriteFile(file, []byte(`<h1>{{title .}}</h1>`), 0644)
+
+	tr, err := NewTemplateRendererFuncs(template.FuncMap{
+		"title": strings.ToUpper,
+	}, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	tr.HTML(w, "jett")
+
+	if output := w.Body.String(); output != "<h1>JETT</h1>" {
+		t.Fatalf("NewTemplateRendererFuncs -> Expected : %s, Output : %s", "<h1>JETT</h1>", output)
+	}
+
+	// Unknown functions fail at parse time
+	if _, err := NewTemplateRenderer(file); err == nil {
+		t.Fatalf("NewTemplateRenderer -> Expected an error for an undefined function")
+	}
+}
+
+func TestHTMLFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/layout.html": {Data: []byte(`<main>{{template "content" .}}</main>`)},
+		"templates/index.html":  {Data: []byte(`{{define "content"}}<p>{{.}}</p>{{end}}`)},
+	}
+
+	w := httptest.NewRecorder()
+	HTMLFS(w, fsys, "Jett", "templates/layout.html", "templates/index.html")
+
+	if w.Body.String() != "<main><p>Jett</p></main>" || w.Header().Get("Content-Type") != "text/html" {
+		t.Fatalf("HTMLFS -> Expected : text/html <main><p>Jett</p></main>, Output : %s %s", w.Header().Get("Content-Type"), w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	HTMLFS(w, fsys, "Jett", "templates/missing.html")
+
+	if w.Code != 500 {
+		t.Fatalf("HTMLFS missing template -> Expected : %d, Output : %d", 500, w.Code)
+	}
+}
+
+func TestHTMLTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	layout := filepath.Join(dir, "layout.html")
+	page := filepath.Join(dir, "page.html")
+
+	os.WriteFile(layout, []byte(`<main>{{template "content" .}}</main>`), 0644)
+	os.WriteFile(page, []byte(`{{define "content"}}<p>{{.}}</p>{{end}}`), 0644)
+
+	tests := []struct {
+		name     string
+		status   int
+		expected string
+	}{
+		{"layout.html", 200, "<main><p>Jett</p></main>"},
+		{"content", 200, "<p>Jett</p>"},
+		{"missing", 500, ""},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		HTMLTemplate(w, test.name, "Jett", layout, page)
+
+		if w.Code != test.status {
+			t.Fatalf("HTMLTemplate %s -> Expected : %d, Output : %d", test.name, test.status, w.Code)
+		}
+
+		if test.status == 200 && w.Body.String() != test.expected {
+			t.Fatalf("HTMLTemplate %s -> Expected : %s, Output : %s", test.name, test.expected, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package jett
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+/* -------------------------- THROTTLED DOWNLOADS ------------------------- */
+
+// ServeFileThrottled serves the file at path limited to bytesPerSec, to avoid saturating
+// the bandwidth with large downloads. Served with http.ServeContent, so Range requests work
+// and interrupted downloads can be resumed.
+//
+// A bytesPerSec of 0 (or less) serves the file without a limit.
+// Responds with 404 Not Found if the file doesn't exist.
+func ServeFileThrottled(w http.ResponseWriter, req *http.Request, path string, bytesPerSec int64) {
+	f, err := os.Open(path)
+	if err != nil {
+		http.NotFound(w, req)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, req)
+		return
+	}
+
+	// No limit
+	if bytesPerSec <= 0 {
+		http.ServeContent(w, req, filepath.Base(path), info.ModTime(), f)
+		return
+	}
+
+	content := newThrottledReader(req.Context(), f, bytesPerSec)
+	http.ServeContent(w, req, filepath.Base(path), info.ModTime(), content)
+}
+
+// io.ReadSeeker limited by a token bucket. The bucket holds up to
+// a tenth of a second worth of bytes so the rate stays smooth.
+// bytesPerSec must be positive.
+type throttledReader struct {
+	ctx    context.Context
+	rs     io.ReadSeeker
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newThrottledReader(ctx context.Context, rs io.ReadSeeker, bytesPerSec int64) *throttledReader {
+	burst := float64(bytesPerSec) / 10
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &throttledReader{
+		ctx:    ctx,
+		rs:     rs,
+		rate:   float64(bytesPerSec),
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Adds the tokens earned since the last refill
+func (tr *throttledReader) refill() {
+	now := time.Now()
+	tr.tokens += now.Sub(tr.last).Seconds() * tr.rate
+	if tr.tokens > tr.burst {
+		tr.tokens = tr.burst
+	}
+	tr.last = now
+}
+
+func (tr *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > int(tr.burst) {
+		p = p[:int(tr.burst)]
+	}
+
+	tr.refill()
+
+	// Wait until there are enough tokens for the read, or the client goes away
+	if missing := float64(len(p)) - tr.tokens; missing > 0 {
+		timer := time.NewTimer(time.Duration(missing / tr.rate * float64(time.Second)))
+		select {
+		case <-timer.C:
+		case <-tr.ctx.Done():
+			timer.Stop()
+			return 0, tr.ctx.Err()
+		}
+		tr.refill()
+	}
+
+	n, err := tr.rs.Read(p)
+	tr.tokens -= float64(n)
+	return n, err
+}
+
+func (tr *throttledReader) Seek(offset int64, whence int) (int64, error) {
+	return tr.rs.Seek(offset, whence)
+}
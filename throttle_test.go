@@ -0,0 +1,93 @@
+package jett
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestServeFileThrottled(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 5<<10)
+
+	filePath := filepath.Join(t.TempDir(), "large.bin")
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := New()
+	r.GET("/download", func(w http.ResponseWriter, req *http.Request) {
+		ServeFileThrottled(w, req, filePath, 100<<10)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	// Resumed download
+	req, err := http.NewRequest("GET", ts.URL+"/download", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Range", "bytes=100-199")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusPartialContent || !bytes.Equal(body, content[100:200]) {
+		t.Fatalf("ServeFileThrottled range -> Expected : 206 %q, Output : %d %q", content[100:200], res.StatusCode, body)
+	}
+
+	// 50KB at 100KB/s with a 10KB burst takes at least 400ms
+	start := time.Now()
+
+	res, err = http.Get(ts.URL + "/download")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ = ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	elapsed := time.Since(start)
+
+	if !bytes.Equal(body, content) {
+		t.Fatalf("ServeFileThrottled -> Expected %d bytes, Output : %d", len(content), len(body))
+	}
+
+	if elapsed < 350*time.Millisecond {
+		t.Fatalf("ServeFileThrottled -> Expected the transfer to take at least 350ms, Output : %s", elapsed)
+	}
+}
+
+func TestServeFileThrottledUnlimited(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100<<10)
+
+	filePath := filepath.Join(t.TempDir(), "large.bin")
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bytesPerSec := range []int64{0, -1} {
+		w := httptest.NewRecorder()
+
+		start := time.Now()
+		ServeFileThrottled(w, httptest.NewRequest("GET", "/download", nil), filePath, bytesPerSec)
+
+		if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), content) {
+			t.Fatalf("ServeFileThrottled %d -> Expected : 200 with the full file, Output : %d %d bytes", bytesPerSec, w.Code, w.Body.Len())
+		}
+
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("ServeFileThrottled %d -> Expected no limit, Output : %s", bytesPerSec, elapsed)
+		}
+	}
+}